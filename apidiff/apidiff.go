@@ -0,0 +1,571 @@
+package apidiff
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+var (
+	ErrStrModuleFileNotFound = "go.mod not found"
+
+	ErrStrFormattedParsingFile = "parsing %v"
+)
+
+const modFile = "go.mod"
+
+// Module is the type-checked API of the packages of a go module
+type Module struct {
+	Path string
+	// Packages are keyed by their directory relative to the module root
+	Packages map[string]*types.Package
+}
+
+// Change is a change to the exported API of a package
+type Change struct {
+	Package    string
+	Message    string
+	Compatible bool
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s", c.Package, c.Message)
+}
+
+// Report lists the API changes between two versions of a module
+type Report struct {
+	Changes []Change
+}
+
+// Incompatible returns the changes which break users of the module
+func (r *Report) Incompatible() []Change {
+	return r.filter(false)
+}
+
+// Compatible returns the changes which only add to the module
+func (r *Report) Compatible() []Change {
+	return r.filter(true)
+}
+
+func (r *Report) filter(compatible bool) []Change {
+	changes := make([]Change, 0)
+	for _, c := range r.Changes {
+		if c.Compatible == compatible {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Required returns the smallest version increment allowed by the changes.
+// Incompatible changes of a module still in initial development (0.x) only require a minor increment.
+func (r *Report) Required(initialDevelopment bool) version.Type {
+	if len(r.Incompatible()) > 0 {
+		if initialDevelopment {
+			return version.Minor
+		}
+		return version.Major
+	} else if len(r.Compatible()) > 0 {
+		return version.Minor
+	}
+	return version.Patch
+}
+
+func (r *Report) add(pkg string, compatible bool, format string, args ...interface{}) {
+	r.Changes = append(r.Changes, Change{
+		Package:    pkg,
+		Message:    fmt.Sprintf(format, args...),
+		Compatible: compatible,
+	})
+}
+
+// Load type-checks the packages of a module from the content of its files, keyed by their slash separated path relative to the module root.
+// Test files, main packages, internal packages and nested modules are ignored. Packages outside of the module and the standard library
+// cannot be resolved, so the types they declare are compared as invalid types.
+func Load(files map[string][]byte) (*Module, error) {
+	gomod, ok := files[modFile]
+	if !ok {
+		return nil, errors.New(ErrStrModuleFileNotFound)
+	}
+
+	l := &loader{
+		module: &Module{
+			Path:     modfile.ModulePath(gomod),
+			Packages: make(map[string]*types.Package),
+		},
+		files:   files,
+		dirs:    make(map[string][]string),
+		fset:    token.NewFileSet(),
+		std:     importer.Default(),
+		checked: make(map[string]*types.Package),
+	}
+
+	nested := make([]string, 0)
+	for name := range files {
+		if name != modFile && path.Base(name) == modFile {
+			nested = append(nested, path.Dir(name))
+		}
+	}
+
+	for name := range files {
+		dir := path.Dir(name)
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || isIgnoredDir(dir, nested) {
+			continue
+		}
+		l.dirs[dir] = append(l.dirs[dir], name)
+	}
+
+	for dir := range l.dirs {
+		if isInternal(dir) {
+			continue
+		}
+		pkg, err := l.check(dir)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != nil && pkg.Name() != "main" {
+			l.module.Packages[dir] = pkg
+		}
+	}
+
+	return l.module, nil
+}
+
+type loader struct {
+	module  *Module
+	files   map[string][]byte
+	dirs    map[string][]string
+	fset    *token.FileSet
+	std     types.Importer
+	checked map[string]*types.Package
+}
+
+// check type-checks the package in dir, ignoring type errors so a partially resolved package can still be compared
+func (l *loader) check(dir string) (*types.Package, error) {
+	if pkg, ok := l.checked[dir]; ok {
+		return pkg, nil
+	}
+	// guards against import cycles
+	l.checked[dir] = nil
+
+	ctxt := build.Default
+	ctxt.OpenFile = func(name string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(string(l.files[name]))), nil
+	}
+	ctxt.JoinPath = path.Join
+
+	names := l.dirs[dir]
+	sort.Strings(names)
+
+	syntax := make([]*ast.File, 0)
+	for _, name := range names {
+		if match, err := ctxt.MatchFile(dir, path.Base(name)); err != nil || !match {
+			continue
+		}
+		f, err := parser.ParseFile(l.fset, name, l.files[name], parser.SkipObjectResolution)
+		if err != nil {
+			return nil, errors.Wrapf(err, ErrStrFormattedParsingFile, name)
+		}
+		syntax = append(syntax, f)
+	}
+	if len(syntax) == 0 {
+		return nil, nil
+	}
+
+	conf := types.Config{
+		Importer: importerFunc(l.importPackage),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(path.Join(l.module.Path, dir), l.fset, syntax, nil)
+	l.checked[dir] = pkg
+
+	return pkg, nil
+}
+
+func (l *loader) importPackage(importPath string) (*types.Package, error) {
+	if importPath == l.module.Path || strings.HasPrefix(importPath, l.module.Path+"/") {
+		dir := strings.TrimPrefix(strings.TrimPrefix(importPath, l.module.Path), "/")
+		if dir == "" {
+			dir = "."
+		}
+		if pkg, err := l.check(dir); pkg != nil || err != nil {
+			return pkg, err
+		}
+	} else if isStandard(importPath) {
+		if pkg, err := l.std.Import(importPath); err == nil {
+			return pkg, nil
+		}
+	}
+
+	prefix, _, _ := module.SplitPathVersion(importPath)
+	pkg := types.NewPackage(importPath, path.Base(prefix))
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+type importerFunc func(string) (*types.Package, error)
+
+func (f importerFunc) Import(importPath string) (*types.Package, error) {
+	return f(importPath)
+}
+
+// Compare reports the changes to the exported API of every package of the old module
+func Compare(old, new *Module) *Report {
+	r := &Report{Changes: make([]Change, 0)}
+
+	for _, dir := range sortedKeys(old.Packages) {
+		oldPkg := old.Packages[dir]
+		newPkg, ok := new.Packages[dir]
+		if !ok {
+			if hasExported(oldPkg) {
+				r.add(dir, false, "package removed")
+			}
+			continue
+		}
+		c := &comparer{report: r, pkg: dir, old: old, new: new}
+		c.comparePackages(oldPkg, newPkg)
+	}
+
+	for _, dir := range sortedKeys(new.Packages) {
+		if _, ok := old.Packages[dir]; !ok && hasExported(new.Packages[dir]) {
+			r.add(dir, true, "package added")
+		}
+	}
+
+	return r
+}
+
+type comparer struct {
+	report *Report
+	pkg    string
+	old    *Module
+	new    *Module
+}
+
+func (c *comparer) comparePackages(oldPkg, newPkg *types.Package) {
+	oldScope, newScope := oldPkg.Scope(), newPkg.Scope()
+
+	for _, name := range oldScope.Names() {
+		oldObj := oldScope.Lookup(name)
+		if !oldObj.Exported() {
+			continue
+		}
+		newObj := newScope.Lookup(name)
+		if newObj == nil || !newObj.Exported() {
+			c.incompatible("%s: removed", name)
+			continue
+		}
+		c.compareObjects(name, oldObj, newObj)
+	}
+
+	for _, name := range newScope.Names() {
+		newObj := newScope.Lookup(name)
+		if newObj.Exported() && oldScope.Lookup(name) == nil {
+			c.compatible("%s: added", name)
+		}
+	}
+}
+
+func (c *comparer) compareObjects(name string, oldObj, newObj types.Object) {
+	if objectKind(oldObj) != objectKind(newObj) {
+		c.incompatible("%s: changed from %s to %s", name, objectKind(oldObj), objectKind(newObj))
+		return
+	}
+
+	switch o := oldObj.(type) {
+	case *types.Const:
+		n := newObj.(*types.Const)
+		if c.oldType(o.Type()) != c.newType(n.Type()) {
+			c.incompatible("%s: type changed from %s to %s", name, c.oldType(o.Type()), c.newType(n.Type()))
+		} else if o.Val().ExactString() != n.Val().ExactString() {
+			c.incompatible("%s: value changed from %s to %s", name, o.Val().ExactString(), n.Val().ExactString())
+		}
+	case *types.Var, *types.Func:
+		if c.oldType(oldObj.Type()) != c.newType(newObj.Type()) {
+			c.incompatible("%s: changed from %s to %s", name, c.oldType(oldObj.Type()), c.newType(newObj.Type()))
+		}
+	case *types.TypeName:
+		c.compareTypes(name, o, newObj.(*types.TypeName))
+	}
+}
+
+func (c *comparer) compareTypes(name string, oldObj, newObj *types.TypeName) {
+	if oldObj.IsAlias() || newObj.IsAlias() {
+		if c.oldType(oldObj.Type()) != c.newType(newObj.Type()) {
+			c.incompatible("%s: changed from %s to %s", name, c.oldType(oldObj.Type()), c.newType(newObj.Type()))
+		}
+		return
+	}
+
+	oldNamed, okOld := oldObj.Type().(*types.Named)
+	newNamed, okNew := newObj.Type().(*types.Named)
+	if !okOld || !okNew {
+		return
+	}
+
+	if c.typeParams(oldNamed.TypeParams(), c.oldType) != c.typeParams(newNamed.TypeParams(), c.newType) {
+		c.incompatible("%s: type parameters changed", name)
+		return
+	}
+
+	switch oldUnder := oldNamed.Underlying().(type) {
+	case *types.Struct:
+		if newUnder, ok := newNamed.Underlying().(*types.Struct); ok {
+			c.compareStructs(name, oldUnder, newUnder)
+		} else {
+			c.incompatible("%s: changed from struct to %s", name, c.newType(newNamed.Underlying()))
+			return
+		}
+	case *types.Interface:
+		if newUnder, ok := newNamed.Underlying().(*types.Interface); ok {
+			c.compareInterfaces(name, oldUnder, newUnder)
+		} else {
+			c.incompatible("%s: changed from interface to %s", name, c.newType(newNamed.Underlying()))
+		}
+		return
+	default:
+		if c.oldType(oldUnder) != c.newType(newNamed.Underlying()) {
+			c.incompatible("%s: changed from %s to %s", name, c.oldType(oldUnder), c.newType(newNamed.Underlying()))
+			return
+		}
+	}
+
+	c.compareMethods(name, oldNamed, newNamed)
+}
+
+func (c *comparer) compareStructs(name string, oldStruct, newStruct *types.Struct) {
+	oldFields := exportedFields(oldStruct)
+	newFields := exportedFields(newStruct)
+
+	for _, fieldName := range sortedKeys(oldFields) {
+		newField, ok := newFields[fieldName]
+		if !ok {
+			c.incompatible("%s.%s: field removed", name, fieldName)
+		} else if c.oldType(oldFields[fieldName].Type()) != c.newType(newField.Type()) {
+			c.incompatible("%s.%s: field type changed from %s to %s", name, fieldName, c.oldType(oldFields[fieldName].Type()), c.newType(newField.Type()))
+		}
+	}
+	for _, fieldName := range sortedKeys(newFields) {
+		if _, ok := oldFields[fieldName]; !ok {
+			c.compatible("%s.%s: field added", name, fieldName)
+		}
+	}
+}
+
+func (c *comparer) compareInterfaces(name string, oldIface, newIface *types.Interface) {
+	oldMethods := interfaceMethods(oldIface)
+	newMethods := interfaceMethods(newIface)
+
+	for _, methodName := range sortedKeys(oldMethods) {
+		newMethod, ok := newMethods[methodName]
+		if !ok {
+			c.incompatible("%s.%s: method removed", name, methodName)
+		} else if c.oldType(oldMethods[methodName].Type()) != c.newType(newMethod.Type()) {
+			c.incompatible("%s.%s: method changed from %s to %s", name, methodName, c.oldType(oldMethods[methodName].Type()), c.newType(newMethod.Type()))
+		}
+	}
+
+	// interfaces with unexported methods cannot be implemented outside the package, so adding methods is safe
+	sealed := len(oldMethods) < oldIface.NumMethods()
+	for _, methodName := range sortedKeys(newMethods) {
+		if _, ok := oldMethods[methodName]; !ok {
+			if sealed {
+				c.compatible("%s.%s: method added", name, methodName)
+			} else {
+				c.incompatible("%s.%s: method added to interface", name, methodName)
+			}
+		}
+	}
+}
+
+func (c *comparer) compareMethods(name string, oldNamed, newNamed *types.Named) {
+	oldMethods := methodSet(types.NewPointer(oldNamed))
+	newMethods := methodSet(types.NewPointer(newNamed))
+	oldValueMethods := methodSet(oldNamed)
+	newValueMethods := methodSet(newNamed)
+
+	for _, methodName := range sortedKeys(oldMethods) {
+		newMethod, ok := newMethods[methodName]
+		if !ok {
+			c.incompatible("%s.%s: method removed", name, methodName)
+		} else if c.oldType(oldMethods[methodName].Type()) != c.newType(newMethod.Type()) {
+			c.incompatible("%s.%s: method changed from %s to %s", name, methodName, c.oldType(oldMethods[methodName].Type()), c.newType(newMethod.Type()))
+		} else if _, ok := newValueMethods[methodName]; oldValueMethods[methodName] != nil && !ok {
+			c.incompatible("%s.%s: method now requires a pointer receiver", name, methodName)
+		}
+	}
+	for _, methodName := range sortedKeys(newMethods) {
+		if _, ok := oldMethods[methodName]; !ok {
+			c.compatible("%s.%s: method added", name, methodName)
+		}
+	}
+}
+
+func (c *comparer) incompatible(format string, args ...interface{}) {
+	c.report.add(c.pkg, false, format, args...)
+}
+
+func (c *comparer) compatible(format string, args ...interface{}) {
+	c.report.add(c.pkg, true, format, args...)
+}
+
+func (c *comparer) oldType(t types.Type) string {
+	return types.TypeString(t, qualifier(c.old))
+}
+
+func (c *comparer) newType(t types.Type) string {
+	return types.TypeString(t, qualifier(c.new))
+}
+
+func (c *comparer) typeParams(params *types.TypeParamList, typeString func(types.Type) string) string {
+	constraints := make([]string, 0, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		constraints = append(constraints, typeString(params.At(i).Constraint()))
+	}
+	return strings.Join(constraints, ", ")
+}
+
+// qualifier names the packages of m by their directory so both versions of a module compare equal after a module path change
+func qualifier(m *Module) types.Qualifier {
+	return func(pkg *types.Package) string {
+		if pkg.Path() == m.Path {
+			return ""
+		} else if strings.HasPrefix(pkg.Path(), m.Path+"/") {
+			return strings.TrimPrefix(pkg.Path(), m.Path+"/")
+		}
+		return pkg.Path()
+	}
+}
+
+func objectKind(obj types.Object) string {
+	switch obj.(type) {
+	case *types.Const:
+		return "const"
+	case *types.Var:
+		return "var"
+	case *types.Func:
+		return "func"
+	case *types.TypeName:
+		return "type"
+	}
+	return "object"
+}
+
+// exportedFields returns the exported fields of s, including the ones promoted from embedded structs
+func exportedFields(s *types.Struct) map[string]*types.Var {
+	fields := make(map[string]*types.Var)
+	seen := make(map[*types.Struct]bool)
+	level := []*types.Struct{s}
+
+	for len(level) > 0 {
+		next := make([]*types.Struct, 0)
+		depth := make(map[string]*types.Var)
+		for _, st := range level {
+			if seen[st] {
+				continue
+			}
+			seen[st] = true
+			for i := 0; i < st.NumFields(); i++ {
+				f := st.Field(i)
+				if _, shadowed := fields[f.Name()]; !shadowed && f.Exported() {
+					depth[f.Name()] = f
+				}
+				if f.Embedded() {
+					t := f.Type()
+					if p, ok := t.(*types.Pointer); ok {
+						t = p.Elem()
+					}
+					if embedded, ok := t.Underlying().(*types.Struct); ok {
+						next = append(next, embedded)
+					}
+				}
+			}
+		}
+		for name, f := range depth {
+			fields[name] = f
+		}
+		level = next
+	}
+
+	return fields
+}
+
+func interfaceMethods(iface *types.Interface) map[string]*types.Func {
+	methods := make(map[string]*types.Func)
+	for i := 0; i < iface.NumMethods(); i++ {
+		if m := iface.Method(i); m.Exported() {
+			methods[m.Name()] = m
+		}
+	}
+	return methods
+}
+
+func methodSet(t types.Type) map[string]types.Object {
+	methods := make(map[string]types.Object)
+	ms := types.NewMethodSet(t)
+	for i := 0; i < ms.Len(); i++ {
+		if obj := ms.At(i).Obj(); obj.Exported() {
+			methods[obj.Name()] = obj
+		}
+	}
+	return methods
+}
+
+func hasExported(pkg *types.Package) bool {
+	for _, name := range pkg.Scope().Names() {
+		if pkg.Scope().Lookup(name).Exported() {
+			return true
+		}
+	}
+	return false
+}
+
+func isStandard(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+	return !strings.Contains(first, ".")
+}
+
+func isInternal(dir string) bool {
+	for _, elem := range strings.Split(dir, "/") {
+		if elem == "internal" {
+			return true
+		}
+	}
+	return false
+}
+
+func isIgnoredDir(dir string, nested []string) bool {
+	for _, elem := range strings.Split(dir, "/") {
+		if elem == "vendor" || elem == "testdata" || (elem != "." && (strings.HasPrefix(elem, ".") || strings.HasPrefix(elem, "_"))) {
+			return true
+		}
+	}
+	for _, n := range nested {
+		if dir == n || strings.HasPrefix(dir, n+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,240 @@
+package apidiff_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nidhhoggr/version-bump/apidiff"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/stretchr/testify/assert"
+)
+
+const legacy = "package legacy\n\nfunc Legacy() {}\n"
+
+const baseline = `package acme
+
+import "io"
+
+const Version = "1.0.0"
+
+var Default = New(nil)
+
+type Client struct {
+	Name   string
+	Output io.Writer
+	secret string
+}
+
+func New(w io.Writer) *Client {
+	return &Client{Output: w}
+}
+
+func (c *Client) Do(path string) error {
+	return nil
+}
+
+type Doer interface {
+	Do(path string) error
+}
+
+type sealed interface {
+	Do(path string) error
+	seal()
+}
+
+type Sealed = sealed
+`
+
+func module(files map[string]string) map[string][]byte {
+	res := map[string][]byte{
+		"go.mod": []byte("module example.com/acme\n\ngo 1.23\n"),
+	}
+	for name, content := range files {
+		res[name] = []byte(content)
+	}
+	return res
+}
+
+func TestApidiff_Compare(t *testing.T) {
+	type test struct {
+		Files                map[string]string
+		ExpectedIncompatible []string
+		ExpectedCompatible   []string
+		ExpectedRequired     version.Type
+	}
+
+	suite := map[string]test{
+		"No Changes": {
+			Files: map[string]string{
+				"acme.go":          baseline,
+				"acme_test.go":     "package acme\n\nfunc TestMore() {}\n",
+				"legacy/legacy.go": legacy,
+			},
+			ExpectedIncompatible: []string{},
+			ExpectedCompatible:   []string{},
+			ExpectedRequired:     version.Patch,
+		},
+		"Added Function And Field": {
+			Files: map[string]string{
+				"acme.go": baseline + `
+func (c *Client) Close() error {
+	return nil
+}
+
+func Must(c *Client, err error) *Client {
+	return c
+}
+`,
+				"legacy/legacy.go": legacy,
+				"sub/sub.go":       "package sub\n\nconst Name = \"sub\"\n",
+				"cmd/main.go": `package main
+
+func main() {}
+`,
+				"internal/x/x.go": "package x\n\nfunc X() {}\n",
+			},
+			ExpectedIncompatible: []string{},
+			ExpectedCompatible: []string{
+				".: Client.Close: method added",
+				".: Must: added",
+				"sub: package added",
+			},
+			ExpectedRequired: version.Minor,
+		},
+		"Changed Signature And Removed Field": {
+			Files: map[string]string{
+				"acme.go": `package acme
+
+import "io"
+
+const Version = "2.0.0"
+
+var Default = New(nil, 0)
+
+type Client struct {
+	Output io.Reader
+	Extra  int
+}
+
+func New(r io.Reader, n int) *Client {
+	return &Client{Output: r}
+}
+
+func (c Client) Do(path string) error {
+	return nil
+}
+
+type Doer interface {
+	Do(path string) error
+	Close() error
+}
+
+type sealed interface {
+	Do(path string) error
+	Close() error
+	seal()
+}
+
+type Sealed = sealed
+`,
+				"legacy/legacy.go": legacy,
+			},
+			ExpectedIncompatible: []string{
+				".: Client.Name: field removed",
+				".: Client.Output: field type changed from io.Writer to io.Reader",
+				".: Doer.Close: method added to interface",
+				".: New: changed from func(w io.Writer) *Client to func(r io.Reader, n int) *Client",
+				".: Version: value changed from \"1.0.0\" to \"2.0.0\"",
+			},
+			ExpectedCompatible: []string{
+				".: Client.Extra: field added",
+			},
+			ExpectedRequired: version.Major,
+		},
+		"Fields Moved To Embedded Struct": {
+			Files: map[string]string{
+				"acme.go": strings.Replace(baseline, `type Client struct {
+	Name   string
+	Output io.Writer
+	secret string
+}`, `type Options struct {
+	Name   string
+	Output io.Writer
+}
+
+type Client struct {
+	Options
+	secret string
+}`, 1),
+				"legacy/legacy.go": legacy,
+			},
+			ExpectedIncompatible: []string{},
+			ExpectedCompatible: []string{
+				".: Client.Options: field added",
+				".: Options: added",
+			},
+			ExpectedRequired: version.Minor,
+		},
+		"Removed Package": {
+			Files: map[string]string{
+				"acme.go": baseline,
+			},
+			ExpectedIncompatible: []string{
+				"legacy: package removed",
+			},
+			ExpectedCompatible: []string{},
+			ExpectedRequired:   version.Major,
+		},
+	}
+
+	oldModule, err := apidiff.Load(module(map[string]string{
+		"acme.go":          baseline,
+		"legacy/legacy.go": legacy,
+		"tools/go.mod":     "module example.com/acme/tools\n",
+		"tools/tools.go":   "package tools\n\nfunc Tool() {}\n",
+	}))
+	assert.Nil(t, err)
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		newModule, err := apidiff.Load(module(test.Files))
+		a.Nil(err)
+
+		report := apidiff.Compare(oldModule, newModule)
+
+		incompatible := make([]string, 0)
+		for _, c := range report.Incompatible() {
+			incompatible = append(incompatible, c.String())
+		}
+		compatible := make([]string, 0)
+		for _, c := range report.Compatible() {
+			compatible = append(compatible, c.String())
+		}
+
+		a.Equal(test.ExpectedIncompatible, incompatible)
+		a.Equal(test.ExpectedCompatible, compatible)
+		a.Equal(test.ExpectedRequired, report.Required(false))
+	}
+}
+
+func TestApidiff_RequiredInInitialDevelopment(t *testing.T) {
+	a := assert.New(t)
+
+	report := &apidiff.Report{Changes: []apidiff.Change{{Package: ".", Message: "New: removed"}}}
+	a.Equal(version.Major, report.Required(false))
+	a.Equal(version.Minor, report.Required(true))
+}
+
+func TestApidiff_LoadErrors(t *testing.T) {
+	a := assert.New(t)
+
+	_, err := apidiff.Load(map[string][]byte{"acme.go": []byte(baseline)})
+	a.EqualError(err, apidiff.ErrStrModuleFileNotFound)
+
+	_, err = apidiff.Load(module(map[string]string{"acme.go": "package acme\n\nfunc {"}))
+	a.ErrorContains(err, "parsing acme.go")
+}
@@ -0,0 +1,154 @@
+package bump
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/nidhhoggr/version-bump/apidiff"
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/nidhhoggr/version-bump/langs/golang"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrStrNoReleaseTag = "no release tag found to compare the API against"
+
+	ErrStrFormattedComparingAPI            = "comparing the API of the go module at %v"
+	ErrStrFormattedInsufficientVersionType = "the API changes since %v require a %v version increment, %v was requested"
+)
+
+// Suggest prints the API changes of the released go module since its latest release tag and returns the version type they require
+func (b *Bump) Suggest(ra *RunArgs) (version.Type, error) {
+	vbd := &versionBumpData{
+		bump:    b,
+		runArgs: ra,
+	}
+
+	if err := vbd.selectRelease(); err != nil {
+		return version.NotAVersion, err
+	}
+
+	report, tag, err := vbd.compareAPI()
+	if err != nil {
+		return version.NotAVersion, err
+	} else if report == nil {
+		return version.NotAVersion, errors.New(ErrStrNoReleaseTag)
+	}
+
+	versionType := report.Required(isInitialDevelopment(tag.Version))
+	console.SuggestedVersionType(version.TypeString(versionType))
+
+	return versionType, nil
+}
+
+// verifyAPI refuses version types smaller than the one required by the API changes since the latest release tag.
+// Modules which were never released, and prerelease increments of the current version, are not verified.
+func (vbd *versionBumpData) verifyAPI() error {
+	if vbd.runArgs.VersionType == version.NotAVersion {
+		return nil
+	}
+
+	report, tag, err := vbd.compareAPI()
+	if err != nil || report == nil {
+		return err
+	}
+
+	required := report.Required(isInitialDevelopment(tag.Version))
+	// version types are ordered from major to patch
+	if vbd.runArgs.VersionType > required {
+		return fmt.Errorf(ErrStrFormattedInsufficientVersionType, tag.Name, version.TypeString(required), version.TypeString(vbd.runArgs.VersionType))
+	}
+
+	return nil
+}
+
+// compareAPI compares the exported API of the released go module at its latest release tag with the working tree.
+// A nil report is returned when the module has no release tag yet.
+func (vbd *versionBumpData) compareAPI() (*apidiff.Report, *git.ReleaseTag, error) {
+	modDir := vbd.apiModuleDir()
+
+	tag, err := vbd.git.LatestReachableTag()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, ErrStrFormattedComparingAPI, modDir)
+	} else if tag == nil {
+		return nil, nil, nil
+	}
+
+	oldFiles, err := git.ReadFiles(tag.Commit, modDir, isGoModuleFile)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, ErrStrFormattedComparingAPI, modDir)
+	}
+
+	newFiles := make(map[string][]byte)
+	err = walkModule(vbd.bump.FS, modDir, func(filepath string, info os.FileInfo) error {
+		name := filepath
+		if modDir != "." {
+			name = strings.TrimPrefix(filepath, modDir+"/")
+		}
+		if info.IsDir() || !isGoModuleFile(name) {
+			return nil
+		}
+		content, err := afero.ReadFile(vbd.bump.FS, filepath)
+		if err != nil {
+			return errors.Wrapf(err, ErrStrFormattedReadingAFile, filepath)
+		}
+		newFiles[name] = content
+		return nil
+	}, true)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, ErrStrFormattedComparingAPI, modDir)
+	}
+
+	oldModule, err := apidiff.Load(oldFiles)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, ErrStrFormattedComparingAPI, modDir)
+	}
+	newModule, err := apidiff.Load(newFiles)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, ErrStrFormattedComparingAPI, modDir)
+	}
+
+	report := apidiff.Compare(oldModule, newModule)
+
+	incompatible := make([]string, 0)
+	for _, c := range report.Incompatible() {
+		incompatible = append(incompatible, c.String())
+	}
+	compatible := make([]string, 0)
+	for _, c := range report.Compatible() {
+		compatible = append(compatible, c.String())
+	}
+	console.APIChanges(tag.Name, incompatible, compatible)
+
+	return report, tag, nil
+}
+
+// apiModuleDir returns the directory of the selected go module, the first go module among the directories
+// of the selected package, or the repository root
+func (vbd *versionBumpData) apiModuleDir() string {
+	if vbd.goModule != nil {
+		return vbd.goModule.Dir
+	}
+	if vbd.pkg != nil {
+		for _, dir := range vbd.pkg.Directories {
+			if exists, _ := afero.Exists(vbd.bump.FS, path.Join(dir, golang.ModFile)); exists {
+				return path.Clean(dir)
+			}
+		}
+	}
+	return "."
+}
+
+func isGoModuleFile(name string) bool {
+	return strings.HasSuffix(name, ".go") || path.Base(name) == golang.ModFile
+}
+
+func isInitialDevelopment(v string) bool {
+	return semver.Major("v"+v) == "v0"
+}
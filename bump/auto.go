@@ -0,0 +1,114 @@
+package bump
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/conventional"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrInferringVersionType = "inferring the version type from commits"
+
+	ErrStrFormattedNoReleasableCommits = "no commits since %v require a release"
+)
+
+// untaggedSince names the start of the commits of a repository without release tags
+const untaggedSince = "the first commit"
+
+// resolveAutoVersionType replaces the auto version type with the increment required by the conventional commits since the latest release tag.
+// Without a release tag, breaking changes depend on whether the version of the files is in initial development, so the
+// version type is only inferred once that version is detected.
+func (vbd *versionBumpData) resolveAutoVersionType() error {
+	tag, err := vbd.git.LatestReachableTag()
+	if err != nil {
+		return errors.Wrap(err, ErrStrInferringVersionType)
+	}
+
+	commits, err := vbd.git.CommitsSince(tag, vbd.releaseDirectories())
+	if err != nil {
+		return errors.Wrap(err, ErrStrInferringVersionType)
+	}
+
+	if tag != nil {
+		return vbd.inferVersionType(commits, tag.Name, isInitialDevelopment(tag.Version))
+	}
+
+	parsed := parseConventionalCommits(commits)
+	if conventional.VersionType(parsed, true) == conventional.VersionType(parsed, false) {
+		return vbd.inferVersionType(commits, untaggedSince, false)
+	}
+	// the version type of a stable version is used until the version of the files is detected
+	vbd.untaggedCommits = commits
+	vbd.setVersionType(conventional.VersionType(parsed, false))
+	return nil
+}
+
+// resolveUntaggedVersionType infers the version type of the commits of an untagged repository from the version of the files
+func (vbd *versionBumpData) resolveUntaggedVersionType(currentVersion string) error {
+	if vbd.untaggedCommits == nil {
+		return nil
+	}
+	commits := vbd.untaggedCommits
+	vbd.untaggedCommits = nil
+	return vbd.inferVersionType(commits, untaggedSince, isInitialDevelopment(currentVersion))
+}
+
+// inferVersionType sets the version type to the increment required by the conventional commits since the release named since
+func (vbd *versionBumpData) inferVersionType(commits []*object.Commit, since string, initialDevelopment bool) error {
+	parsed := make([]*conventional.Commit, 0)
+	drivers := make([]string, 0)
+	for _, commit := range commits {
+		c, ok := conventional.Parse(commit.Message)
+		if !ok {
+			continue
+		}
+		parsed = append(parsed, c)
+		if t := c.VersionType(initialDevelopment); t != version.NotAVersion {
+			drivers = append(drivers, fmt.Sprintf("%-5s %s %s", version.TypeString(t), commit.Hash.String()[:git.ShortHashLength], c.Header))
+		}
+	}
+
+	versionType := conventional.VersionType(parsed, initialDevelopment)
+	if versionType == version.NotAVersion {
+		return fmt.Errorf(ErrStrFormattedNoReleasableCommits, since)
+	}
+
+	console.ConventionalCommits(since, drivers)
+	console.InferredVersionType(version.TypeString(versionType))
+
+	vbd.setVersionType(versionType)
+	return nil
+}
+
+// setVersionType replaces the version type of the run arguments, which are shared with the caller
+func (vbd *versionBumpData) setVersionType(versionType version.Type) {
+	ra := *vbd.runArgs
+	ra.VersionType = versionType
+	vbd.runArgs = &ra
+}
+
+// parseConventionalCommits returns the commits following the conventional commits specification
+func parseConventionalCommits(commits []*object.Commit) []*conventional.Commit {
+	parsed := make([]*conventional.Commit, 0)
+	for _, commit := range commits {
+		if c, ok := conventional.Parse(commit.Message); ok {
+			parsed = append(parsed, c)
+		}
+	}
+	return parsed
+}
+
+// releaseDirectories returns the directories of the selected go module or package, or nil for the whole repository
+func (vbd *versionBumpData) releaseDirectories() []string {
+	if vbd.goModule != nil {
+		return []string{vbd.goModule.Dir}
+	} else if vbd.pkg != nil {
+		return vbd.pkg.Directories
+	}
+	return nil
+}
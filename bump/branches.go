@@ -0,0 +1,188 @@
+package bump
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrFormattedMaintenanceBranch        = "only patch versions and prereleases are released from branch %v, not %v versions"
+	ErrStrFormattedPrereleaseChannel        = "branch %v releases %v prereleases, not %v"
+	ErrStrFormattedInvalidPrereleaseChannel = "branch pattern %v has the prerelease type %q, expected one of %v"
+	ErrStrFormattedInvalidReleaseBranch     = "release branch template %q"
+	ErrStrFormattedInvalidPrereleaseCounter = "prerelease counter %q, expected one of %v"
+	ErrStrFormattedPrereleaseIdentifier     = "prerelease identifier of branch %v"
+)
+
+// withBranches validates the [branches] section: its patterns, the release branch template and the prerelease types
+func (b *Bump) withBranches(bc *git.BranchesConfig) error {
+	patterns := append(append([]string{}, bc.Main...), bc.Maintenance...)
+	for pattern, prerelease := range bc.Prereleases {
+		patterns = append(patterns, pattern)
+		if !slices.Contains(version.PrereleaseTypeStrings, prerelease) {
+			return fmt.Errorf(ErrStrFormattedInvalidPrereleaseChannel, pattern, prerelease, strings.Join(version.PrereleaseTypeStrings, ", "))
+		}
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Wrapf(err, ErrStrFormattedInvalidBranch, pattern)
+		}
+	}
+
+	if bc.ReleaseBranch != "" {
+		name, err := bc.ReleaseBranchName("1.2.0")
+		if err != nil {
+			return errors.Wrapf(err, ErrStrFormattedInvalidReleaseBranch, bc.ReleaseBranch)
+		} else if strings.TrimSpace(name) == "" {
+			return fmt.Errorf(ErrStrFormattedInvalidReleaseBranch, bc.ReleaseBranch)
+		}
+	}
+
+	if bc.PrereleaseIdentifier != "" {
+		if _, err := bc.RenderPrereleaseIdentifier("feature/example"); err != nil {
+			return err
+		}
+	}
+	counters := []string{git.PrereleaseCounterTags, git.PrereleaseCounterCommits}
+	if bc.PrereleaseCounter != "" && !slices.Contains(counters, bc.PrereleaseCounter) {
+		return fmt.Errorf(ErrStrFormattedInvalidPrereleaseCounter, bc.PrereleaseCounter, strings.Join(counters, ", "))
+	}
+
+	b.Branches = *bc
+	return nil
+}
+
+// applyBranchPolicy rejects the version types which the policy of the current branch does not allow, before any file is changed.
+// Maintenance branches only release patch versions and prereleases, and branches tied to a prerelease type release it by default.
+func (vbd *versionBumpData) applyBranchPolicy() error {
+	bc := &vbd.bump.Branches
+	ra := vbd.runArgs
+	if len(bc.Maintenance) == 0 && len(bc.Prereleases) == 0 {
+		return nil
+	}
+
+	branch, err := vbd.git.Branch()
+	if err != nil {
+		return err
+	}
+
+	if matchBranch(bc.Maintenance, branch) && (ra.VersionType == version.Major || ra.VersionType == version.Minor) {
+		return fmt.Errorf(ErrStrFormattedMaintenanceBranch, branch, version.TypeString(ra.VersionType))
+	}
+
+	if prerelease := branchPrerelease(bc.Prereleases, branch); prerelease != version.NotAPrerelease {
+		if ra.PrereleaseType == version.NotAPrerelease {
+			ra.PrereleaseType = prerelease
+		} else if ra.PrereleaseType != prerelease {
+			return fmt.Errorf(ErrStrFormattedPrereleaseChannel, branch, version.PrereleaseString(prerelease), version.PrereleaseString(ra.PrereleaseType))
+		}
+	}
+
+	return nil
+}
+
+// resolvePrereleaseIdentifier names branch prereleases after the current branch, sanitized to follow the semver rules,
+// and counts the commits reachable from HEAD when they are the counter
+func (vbd *versionBumpData) resolvePrereleaseIdentifier() error {
+	bc := &vbd.bump.Branches
+	branch, err := vbd.git.Branch()
+	if err != nil {
+		return err
+	}
+
+	identifier, err := bc.RenderPrereleaseIdentifier(version.SanitizePrereleaseIdentifier(branch))
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedPrereleaseIdentifier, branch)
+	}
+	if err := version.ValidatePrereleaseIdentifier(identifier); err != nil {
+		return errors.Wrapf(err, ErrStrFormattedPrereleaseIdentifier, branch)
+	}
+	vbd.prereleaseIdentifier = identifier
+
+	if bc.PrereleaseCounter == git.PrereleaseCounterCommits {
+		commits, err := vbd.git.CommitsSince(nil, nil)
+		if err != nil {
+			return errors.Wrapf(err, ErrStrFormattedPrereleaseIdentifier, branch)
+		}
+		vbd.prereleaseCommits = len(commits)
+	}
+
+	return nil
+}
+
+// createReleaseBranch creates, and pushes along with the release, the release branch of a X.Y.0 version released from a main branch
+func (vbd *versionBumpData) createReleaseBranch() error {
+	bc := &vbd.bump.Branches
+	if !bc.CreateReleaseBranch {
+		return nil
+	}
+
+	v, err := semver.NewVersion(vbd.versionStr)
+	if err != nil || v.Patch() != 0 || v.Prerelease() != "" {
+		return nil
+	}
+
+	branch, err := vbd.git.Branch()
+	if err != nil {
+		return err
+	} else if !matchBranch(bc.Main, branch) {
+		return nil
+	}
+
+	name, err := bc.ReleaseBranchName(vbd.versionStr)
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedInvalidReleaseBranch, bc.ReleaseBranch)
+	}
+	console.CreatingBranch(name, vbd.runArgs.IsDryRun)
+	if vbd.runArgs.IsDryRun {
+		return nil
+	}
+
+	head, err := vbd.git.Repository.Head()
+	if err != nil {
+		return errors.Wrap(err, git.ErrStrReadingHead)
+	}
+	if err := vbd.git.CreateBranch(name, head.Hash()); err != nil {
+		return err
+	}
+
+	if vbd.runArgs.Push {
+		return vbd.git.PushBranch(&vbd.bump.Push, name)
+	}
+	return nil
+}
+
+// matchBranch reports whether branch matches one of patterns, which are validated when the configuration is loaded
+func matchBranch(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, branch); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// branchPrerelease returns the prerelease type of the first pattern matching branch, in lexical order, or NotAPrerelease
+func branchPrerelease(prereleases map[string]string, branch string) version.PrereleaseType {
+	patterns := make([]string, 0, len(prereleases))
+	for pattern := range prereleases {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if matchBranch([]string{pattern}, branch) {
+			return version.FromPrereleaseTypeString(prereleases[pattern])
+		}
+	}
+	return version.NotAPrerelease
+}
@@ -5,7 +5,10 @@ import (
 	"github.com/nidhhoggr/version-bump/langs/docker"
 	"github.com/nidhhoggr/version-bump/langs/golang"
 	"github.com/nidhhoggr/version-bump/langs/js"
+	"github.com/nidhhoggr/version-bump/langs/plaintext"
+	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
@@ -15,7 +18,6 @@ import (
 	"github.com/BurntSushi/toml"
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/go-git/go-billy/v5"
-	"github.com/go-git/go-billy/v5/osfs"
 	"github.com/nidhhoggr/version-bump/console"
 	"github.com/nidhhoggr/version-bump/git"
 	"github.com/nidhhoggr/version-bump/gpg"
@@ -44,7 +46,11 @@ var (
 	ErrStrFormattedParsingVersionFromFileAndVersion = "parsing semantic version at file %v from version (%s)"
 	ErrStrFormattedBumpingVersion                   = "bumping version %v"
 	ErrStrFormattedSettingVersionInFile             = "setting new version on content of a file %v"
+	ErrStrFormattedLocatingVersionInFile            = "locating version in file %v"
 	ErrStrFormattedInconsistentVersioning           = "inconsistent versioning: %s"
+	ErrStrFormattedPackageNotFound                  = "no package named %v is configured"
+	ErrStrFormattedInvalidTagTemplate               = "tag template %q must contain the version once"
+	ErrStrFormattedUnexpectedContentInVersionFile   = "expected %v to contain only a version, found extra content on line %d: %q"
 )
 
 func init() {
@@ -54,13 +60,41 @@ func init() {
 	}
 }
 
+// New opens the repository containing dir, discovered by walking up to the root of its working tree, see git.Discover.
+// The .bump file is read from dir when it contains one, and from the root of the working tree otherwise.
 func New(dir string) (*Bump, error) {
-	fs := afero.NewOsFs()
-	meta := osfs.New(path.Join(dir, ".git"))
-	data := osfs.New(dir)
-	return From(fs, meta, data, dir)
+	location, err := git.Discover(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	configDir, err := configDirectory(location.Root, dir)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingConfigFile)
+	}
+
+	fs := afero.NewBasePathFs(afero.NewOsFs(), location.Root)
+	return From(fs, location.Meta(), location.Data(), configDir)
 }
 
+// configDirectory returns dir relative to root when it contains a .bump file, or the root otherwise
+func configDirectory(root string, dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(filepath.Join(abs, ConfigFile)); err != nil {
+		return ".", nil
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// From returns a Bump of the repository of meta and data whose files are read from fs. The .bump file is read from dir,
+// relative to the root of fs, and the directories it configures are relative to dir.
 func From(fs afero.Fs, meta, data billy.Filesystem, dir string) (*Bump, error) {
 
 	gitInstance, err := git.New(meta, data)
@@ -71,13 +105,14 @@ func From(fs afero.Fs, meta, data billy.Filesystem, dir string) (*Bump, error) {
 	o := &Bump{
 		FS:        fs,
 		Git:       gitInstance,
+		Dir:       dir,
 		WaitGroup: new(sync.WaitGroup),
 	}
 
 	dirs := []string{dir}
 
 	// check for config file
-	content, err := readFile(fs, ".bump")
+	content, err := readFile(fs, path.Join(dir, ConfigFile))
 	if err != nil {
 		if strings.Contains(err.Error(), ErrStrNoSuchFileOrDirectory) || strings.Contains(err.Error(), ErrStrFileDoesNotExist) {
 			//return default settings if config file not found
@@ -93,8 +128,52 @@ func From(fs afero.Fs, meta, data billy.Filesystem, dir string) (*Bump, error) {
 		return nil, errors.Wrap(err, ErrStrParsingConfigFile)
 	}
 
-	//map ConfigDecoder to the Configuration struct
-	bcr := reflect.ValueOf(cf).Elem()
+	o.Configuration = configurationFrom(&cf.LanguagesDecoder)
+	o.Dependents = cf.Dependents
+	o.Changelog = cf.Changelog
+	o.Push = cf.Push
+	o.Checks = cf.Checks
+	o.TagOnly = cf.Git.TagOnly
+	o.DescribeConfig = cf.Describe
+
+	for i := range o.Configuration {
+		o.Configuration[i].Directories = relativeTo(dir, o.Configuration[i].Directories)
+	}
+	for i := range cf.Package {
+		cf.Package[i].Directories = relativeTo(dir, cf.Package[i].Directories)
+		o.Packages = append(o.Packages, packageFrom(&cf.Package[i]))
+	}
+
+	if err := o.withGitTemplates(&cf.Git); err != nil {
+		return nil, errors.Wrap(err, ErrStrParsingConfigFile)
+	}
+	if err := o.withBranches(&cf.Branches); err != nil {
+		return nil, errors.Wrap(err, ErrStrParsingConfigFile)
+	}
+
+	console.Debug("Bump.From()", fmt.Sprintf("configuration: %-v", o))
+
+	return o, nil
+}
+
+// relativeTo joins dirs to dir, which is returned alone when there are no dirs
+func relativeTo(dir string, dirs []string) []string {
+	if path.Clean(dir) == "." {
+		return dirs
+	} else if len(dirs) == 0 {
+		return []string{dir}
+	}
+	joined := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		joined = append(joined, path.Join(dir, d))
+	}
+	return joined
+}
+
+// configurationFrom maps the enabled languages of a LanguagesDecoder to a Configuration
+func configurationFrom(ld *langs.LanguagesDecoder) Configuration {
+	configuration := make(Configuration, 0)
+	bcr := reflect.ValueOf(ld).Elem()
 	bcrType := bcr.Type()
 	for i := 0; i < bcr.NumField(); i++ {
 		langI := bcr.Field(i).Interface()
@@ -106,25 +185,114 @@ func From(fs afero.Fs, meta, data billy.Filesystem, dir string) (*Bump, error) {
 					if lang.Name == "" {
 						lang.Name = "Generic"
 					}
-					o.Configuration = append(o.Configuration, lang)
+					configuration = append(configuration, lang)
 				}
 			}
 		} else {
 			lang := langI.(langs.Config)
 			if lang.Enabled {
 				lang.Name = bcrType.Field(i).Name
-				o.Configuration = append(o.Configuration, lang)
+				configuration = append(configuration, lang)
 			}
 		}
 	}
+	return configuration
+}
 
-	console.Debug("Bump.From()", fmt.Sprintf("configuration: %-v", o))
+// packageFrom maps a PackageDecoder to a Package whose language directories are relative to the package directories.
+// Packages without any enabled languages detect all supported languages automatically.
+func packageFrom(pd *langs.PackageDecoder) Package {
+	dirs := pd.Directories
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
 
-	return o, nil
+	configuration := configurationFrom(&pd.LanguagesDecoder)
+	if len(configuration) == 0 {
+		configuration = defaultConfiguration(dirs, true)
+	} else {
+		for i := range configuration {
+			if len(configuration[i].Directories) == 0 {
+				configuration[i].Directories = dirs
+				continue
+			}
+			langDirs := make([]string, 0)
+			for _, dir := range dirs {
+				for _, langDir := range configuration[i].Directories {
+					langDirs = append(langDirs, path.Join(dir, langDir))
+				}
+			}
+			configuration[i].Directories = langDirs
+		}
+	}
+
+	tagTemplate := pd.Tag
+	if tagTemplate == "" {
+		tagTemplate = fmt.Sprintf("%s-%s", pd.Name, git.DefaultTagTemplate)
+	}
+
+	return Package{
+		Name:          pd.Name,
+		TagTemplate:   tagTemplate,
+		Directories:   dirs,
+		Configuration: configuration,
+	}
+}
+
+// withGitTemplates applies the templates of the [git] section, and validates them along with the tag templates of packages
+func (b *Bump) withGitTemplates(gc *git.Config) error {
+	if gc.TagName != "" {
+		b.Git.TagTemplate = gc.TagName
+	}
+	b.Git.CommitMessageTemplate = gc.CommitMessage
+	b.Git.TagMessageTemplate = gc.TagMessage
+	b.Git.LightweightTags = gc.LightweightTags
+	b.Git.AliasTagTemplates = gc.AliasTags
+	b.Git.IncludeStaged = gc.IncludeStaged
+	b.Git.RunHooks = gc.RunHooks
+
+	for _, t := range append([]string{gc.CommitMessage, gc.TagMessage}, gc.AliasTags...) {
+		if _, err := git.Render(t, &git.Release{Version: "0.0.0"}); err != nil {
+			return err
+		}
+	}
+
+	tagTemplates := []string{b.Git.TagTemplate}
+	for i := range b.Packages {
+		tagTemplates = append(tagTemplates, b.Packages[i].TagTemplate)
+	}
+	for _, t := range tagTemplates {
+		if _, err := git.Render(t, &git.Release{Version: "0.0.0"}); err != nil {
+			return err
+		} else if _, ok := git.VersionFromTag(t, git.TagName(t, "0.0.0")); !ok {
+			return fmt.Errorf(ErrStrFormattedInvalidTagTemplate, t)
+		}
+	}
+
+	return nil
+}
+
+// selectPackage scopes the release to the configuration and tag template of the named package
+func (vbd *versionBumpData) selectPackage(name string) error {
+	b := vbd.bump
+	for i := range b.Packages {
+		if b.Packages[i].Name == name {
+			vbd.pkg = &b.Packages[i]
+			vbd.configuration = b.Packages[i].Configuration
+			vbd.git.TagTemplate = b.Packages[i].TagTemplate
+			return nil
+		}
+	}
+	return fmt.Errorf(ErrStrFormattedPackageNotFound, name)
 }
 
 func (b *Bump) withConfiguration(dirs []string, enabledByDefault bool) *Bump {
-	b.Configuration = Configuration{
+	b.Configuration = defaultConfiguration(dirs, enabledByDefault)
+	return b
+}
+
+func defaultConfiguration(dirs []string, enabledByDefault bool) Configuration {
+	return Configuration{
 		langs.Config{
 			Name:        docker.Name,
 			Enabled:     enabledByDefault,
@@ -140,8 +308,56 @@ func (b *Bump) withConfiguration(dirs []string, enabledByDefault bool) *Bump {
 			Enabled:     enabledByDefault,
 			Directories: dirs,
 		},
+		langs.Config{
+			Name:        plaintext.Name,
+			Enabled:     enabledByDefault,
+			Directories: dirs,
+		},
 	}
-	return b
+}
+
+// scopedTo returns a copy of the configuration limiting every language to the directories within dir, or dir itself when none are
+func (c Configuration) scopedTo(dir string) Configuration {
+	scoped := append(Configuration{}, c...)
+	if dir == "." {
+		return scoped
+	}
+	for i := range scoped {
+		dirs := make([]string, 0)
+		for _, d := range scoped[i].GetDirectories() {
+			d = path.Clean(d)
+			if d == dir || strings.HasPrefix(d, dir+"/") {
+				dirs = append(dirs, d)
+			}
+		}
+		if len(dirs) == 0 {
+			dirs = []string{dir}
+		}
+		scoped[i].Directories = dirs
+	}
+	return scoped
+}
+
+// selectRelease scopes the release to the package and the go module selected by the run arguments.
+// The scope is kept in the run data, leaving the Bump as configured for its later runs.
+func (vbd *versionBumpData) selectRelease() error {
+	gitInstance := *vbd.bump.Git
+	vbd.git = &gitInstance
+	vbd.configuration = vbd.bump.Configuration
+
+	if vbd.runArgs.Package != "" {
+		if err := vbd.selectPackage(vbd.runArgs.Package); err != nil {
+			return err
+		}
+	}
+
+	if vbd.runArgs.GoModule != "" {
+		if err := vbd.selectGoModule(vbd.runArgs.GoModule); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (b *Bump) Bump(ra *RunArgs) error {
@@ -152,22 +368,166 @@ func (b *Bump) Bump(ra *RunArgs) error {
 		bump:             b,
 		versionsDetected: NewVersionDetector(),
 		runArgs:          ra,
+		originals:        newOriginalFiles(),
+	}
+
+	if err := vbd.selectRelease(); err != nil {
+		return err
+	}
+
+	if err := vbd.checkRepository(); err != nil {
+		return err
+	}
+
+	if err := vbd.loadReleaseTags(); err != nil {
+		return err
+	}
+
+	if ra.VersionType == version.Auto {
+		if err := vbd.resolveAutoVersionType(); err != nil {
+			return err
+		}
+	}
+
+	if vbd.runArgs.VerifyAPI {
+		if err := vbd.verifyAPI(); err != nil {
+			return err
+		}
+	}
+
+	if err := vbd.applyBranchPolicy(); err != nil {
+		return err
+	}
+
+	if ra.PrereleaseType == version.BranchPrerelease {
+		if err := vbd.resolvePrereleaseIdentifier(); err != nil {
+			return err
+		}
+	}
+
+	if ra.TagOnly || b.TagOnly {
+		return vbd.tagRelease()
+	}
+
+	if b.Changelog.Enabled && b.Changelog.Unreleased {
+		if err := vbd.checkChangelog(); err != nil {
+			return err
+		}
+	}
+
+	files, err := vbd.bumpVersions()
+	if err != nil {
+		return vbd.rollbackOnError(err)
+	}
+
+	modDir := "."
+	if vbd.goModule != nil {
+		modDir = vbd.goModule.Dir
+	}
+	newModPath := ""
+	if vbd.configuration.migratesModule() {
+		migratedFiles, modPath, err := vbd.migrateGoModule(modDir)
+		if err != nil {
+			return vbd.rollbackOnError(err)
+		}
+		newModPath = modPath
+		files = appendUnique(files, migratedFiles...)
+	}
+
+	if vbd.goModule != nil {
+		if newModPath == "" {
+			newModPath = vbd.goModule.Path
+		}
+		dependentFiles, err := vbd.updateGoModuleDependents(newModPath)
+		if err != nil {
+			return vbd.rollbackOnError(err)
+		}
+		files = appendUnique(files, dependentFiles...)
+	}
+
+	tags := make([]git.Tag, 0)
+	if vbd.pkg != nil && b.Dependents.Update {
+		dependentFiles, dependentTags, err := vbd.updateDependents(map[string]bool{})
+		if err != nil {
+			return vbd.rollbackOnError(err)
+		}
+		files = appendUnique(files, dependentFiles...)
+		tags = append(tags, dependentTags...)
 	}
 
+	release := &git.Release{
+		Version:    vbd.versionStr,
+		OldVersion: vbd.oldVersion(),
+	}
+
+	if b.Changelog.Enabled && len(files) != 0 {
+		changelogFile, section, err := vbd.writeChangelog()
+		if err != nil {
+			return vbd.rollbackOnError(err)
+		}
+		files = appendUnique(files, changelogFile)
+		release.Changelog = section
+		if b.Changelog.TagMessage && vbd.git.TagMessageTemplate == "" {
+			vbd.git.TagMessageTemplate = "{{.Changelog}}"
+		}
+	}
+	release.Files = files
+
+	if !ra.IsDryRun {
+
+		if len(files) != 0 {
+
+			gpgEntity, err := vbd.gpgEntity()
+			if err != nil {
+				return vbd.rollbackOnError(err)
+			}
+
+			console.CommittingChanges()
+
+			if err := vbd.git.Save(release, gpgEntity, tags...); err != nil {
+				// no commit is created when a hook fails, while the release commit is kept when tagging fails
+				var hookErr *git.HookError
+				if errors.As(err, &hookErr) {
+					return vbd.rollbackOnError(err)
+				}
+				return err
+			}
+
+			if ra.Push {
+				console.PushingChanges(b.Push.RemoteName())
+				if err := vbd.git.Push(&b.Push, release, tags...); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(files) != 0 {
+		return vbd.createReleaseBranch()
+	}
+
+	return nil
+}
+
+// bumpVersions increments the version in the files of every enabled language once all of them agree on the current version
+func (vbd *versionBumpData) bumpVersions() ([]string, error) {
+	b := vbd.bump
 	files := make([]string, 0)
 
-	b.errChanVersionGathering = make(chan error, 1)
+	b.versionsGathered = make(chan struct{})
+	b.errVersionGathering = nil
 	b.errChanPostProcessing = make(chan error, 1)
 
-	for i := range b.Configuration {
-		langConfig := b.Configuration[i]
+	for i := range vbd.configuration {
+		langConfig := vbd.configuration[i]
 		if langConfig.Enabled {
 
-			langSettings := langs.GetLanguageByName(langConfig.Name)
+			// the settings are copied since the configuration overrides them for this release only
+			langSettings := *langs.GetLanguageByName(langConfig.Name)
 			console.Debug("Bump.Bump()", fmt.Sprintf("loading lang settings %-v from %s", langSettings, langConfig.Name))
-			modifiedFiles, err := vbd.bumpComponent(&langConfig, langSettings)
+			modifiedFiles, err := vbd.bumpComponent(&langConfig, &langSettings)
 			if err != nil {
-				return errors.Wrapf(err, ErrStrFormattedIncrementingInLangProject, langConfig.Name)
+				return []string{}, errors.Wrapf(err, ErrStrFormattedIncrementingInLangProject, langConfig.Name)
 			}
 			files = append(files, modifiedFiles...)
 		}
@@ -180,54 +540,44 @@ func (b *Bump) Bump(ra *RunArgs) error {
 		err = fmt.Errorf(ErrStrFormattedInconsistentVersioning, versionsDetected.String())
 	} else if len(versionsDetected) == 0 {
 		err = errors.New(ErrStrZeroFilesUpdated)
+	} else {
+		err = vbd.checkReleaseTags()
 	}
 
-	b.errChanVersionGathering <- err
-	//notify the goroutines we're ready to start processing if err is nil
-	close(b.errChanVersionGathering)
+	//notify every goroutine at once, they only start processing if err is nil
+	b.errVersionGathering = err
+	close(b.versionsGathered)
 	//wait for all the goroutines to finish
 	b.WaitGroup.Wait()
 
 	if err != nil {
-		return err
+		return []string{}, err
 	}
 
 	select {
 	case err = <-b.errChanPostProcessing:
-		return err
+		return []string{}, err
 		//a goroutine errored
 	default:
 		//all goroutines finished successfully
 	}
 
-	if !ra.IsDryRun {
-
-		if len(files) != 0 {
-
-			var gpgEntity *openpgp.Entity
-
-			if ra.PassphrasePrompt != nil {
-				gpgSigningKey, err := b.Git.GetSigningKeyFromConfig(GitConfigParser)
-				if err != nil {
-					return errors.Wrap(err, ErrStrRetrievingGpgConfiguration)
-				}
-				if gpgSigningKey != "" {
-					gpgEntity, err = vbd.passphrasePromptWithRetries(gpgSigningKey, 3, 0)
-					if err != nil {
-						return err
-					}
-				}
-			}
-
-			console.CommittingChanges()
+	return files, nil
+}
 
-			if err := b.Git.Save(files, vbd.versionStr, gpgEntity); err != nil {
-				return err
-			}
-		}
+// gpgEntity returns the signing key of the git configuration unlocked with the passphrase prompt, or nil when nothing is signed
+func (vbd *versionBumpData) gpgEntity() (*openpgp.Entity, error) {
+	if vbd.runArgs.PassphrasePrompt == nil {
+		return nil, nil
 	}
-
-	return nil
+	gpgSigningKey, err := vbd.git.GetSigningKeyFromConfig(GitConfigParser)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrRetrievingGpgConfiguration)
+	}
+	if gpgSigningKey == "" {
+		return nil, nil
+	}
+	return vbd.passphrasePromptWithRetries(gpgSigningKey, 3, 0)
 }
 
 func (vbd *versionBumpData) passphrasePromptWithRetries(gpgSigningKey string, retryLimit int, retryCount int) (*openpgp.Entity, error) {
@@ -287,6 +637,7 @@ func (vbd *versionBumpData) bumpComponent(langConfig *langs.Config, langSettings
 			modifiedFiles, err := vbd.incrementVersion(
 				dir,
 				filteredFiles,
+				langConfig,
 				langSettings,
 			)
 			if err != nil {
@@ -300,7 +651,7 @@ func (vbd *versionBumpData) bumpComponent(langConfig *langs.Config, langSettings
 	return files, nil
 }
 
-func (vbd *versionBumpData) incrementVersion(dir string, files []string, langSettings *langs.DefaultSettings) ([]string, error) {
+func (vbd *versionBumpData) incrementVersion(dir string, files []string, langConfig *langs.Config, langSettings *langs.DefaultSettings) ([]string, error) {
 	var identified bool
 	modifiedFiles := make([]string, 0)
 
@@ -311,7 +662,27 @@ func (vbd *versionBumpData) incrementVersion(dir string, files []string, langSet
 		if err != nil {
 			return []string{}, errors.Wrapf(err, ErrStrFormattedReadingAFile, file)
 		}
+		if langSettings.SingleVersion {
+			if err := checkSingleVersion(filepath, fileContent); err != nil {
+				return []string{}, err
+			}
+		}
 		var oldVersion *version.Version
+		if langSettings.Locate != nil {
+			located, confirmed, err := vbd.locateVersions(langConfig, langSettings, file, filepath)
+			if errors.Is(err, langs.ErrNoVersionLocated) {
+				continue
+			} else if err != nil {
+				return []string{}, err
+			}
+			if located {
+				identified = true
+				if confirmed {
+					modifiedFiles = append(modifiedFiles, filepath)
+				}
+				continue
+			}
+		}
 		// get current version
 		if langSettings.Regex != nil {
 		outerR:
@@ -346,6 +717,7 @@ func (vbd *versionBumpData) incrementVersion(dir string, files []string, langSet
 								}
 							}
 
+							vbd.bump.WaitGroup.Add(1)
 							go vbd.runRegexReplacement(langSettings, fileContent, lineNumber, filepath, oldVersionStr)
 
 							modifiedFiles = append(modifiedFiles, filepath)
@@ -390,6 +762,7 @@ func (vbd *versionBumpData) incrementVersion(dir string, files []string, langSet
 						}
 					}
 
+					vbd.bump.WaitGroup.Add(1)
 					go vbd.runJsonFieldReplacement(langSettings, fileContent, field, filepath, oldVersionStr)
 
 					modifiedFiles = append(modifiedFiles, filepath)
@@ -406,13 +779,88 @@ func (vbd *versionBumpData) incrementVersion(dir string, files []string, langSet
 	return modifiedFiles, nil
 }
 
+// locateVersions bumps every version found by the language Locator.
+// It reports whether any version was located and whether the change to the file was confirmed.
+func (vbd *versionBumpData) locateVersions(langConfig *langs.Config, langSettings *langs.DefaultSettings, file string, filepath string) (bool, bool, error) {
+	content, err := afero.ReadFile(vbd.bump.FS, filepath)
+	if err != nil {
+		return false, false, errors.Wrapf(err, ErrStrFormattedReadingAFile, file)
+	}
+
+	locations, err := langSettings.Locate(content, langConfig)
+	if err != nil {
+		return false, false, errors.Wrapf(err, ErrStrFormattedLocatingVersionInFile, filepath)
+	}
+
+	var oldVersionStr string
+	changed := make([][]int, 0, len(locations))
+	for _, location := range locations {
+		oldVersion, err := version.New(string(content[location[0]:location[1]]))
+		if err != nil {
+			return false, false, errors.Wrapf(err, ErrStrFormattedParsingVersionFromFileAndVersion, filepath, content[location[0]:location[1]])
+		}
+		oldVersionStr = oldVersion.String()
+		versionsAreSame, err := vbd.incrementAndCompareVersions(oldVersion)
+		if err != nil {
+			return false, false, errors.Wrapf(err, ErrStrFormattedBumpingVersion, filepath)
+		} else if !versionsAreSame {
+			changed = append(changed, location)
+		}
+	}
+
+	if len(changed) == 0 {
+		return false, false, nil
+	}
+
+	if !vbd.runArgs.IsDryRun {
+		confirmed, err := vbd.versionConfirmationPrompt(oldVersionStr, file)
+		if err != nil {
+			return true, false, errors.Wrap(err, ErrStrDuringConfirmationPrompt)
+		} else if !confirmed {
+			//the file is skipped entirely rather than falling back to Regex or JSONFields
+			return true, false, nil
+		}
+	}
+
+	vbd.bump.WaitGroup.Add(1)
+	go vbd.runLocatorReplacement(langSettings, content, changed, filepath, oldVersionStr)
+
+	return true, true, nil
+}
+
+// oldVersion returns the version detected in every bumped file
+func (vbd *versionBumpData) oldVersion() string {
+	for v := range vbd.versionsDetected {
+		return v
+	}
+	return ""
+}
+
 func (vbd *versionBumpData) incrementAndCompareVersions(oldVersion *version.Version) (bool, error) {
 	oldVersionStr := oldVersion.String()
 	vbd.versionsDetected[oldVersionStr]++
+	if err := vbd.resolveUntaggedVersionType(oldVersionStr); err != nil {
+		return false, err
+	}
+	if vbd.prereleaseIdentifier != "" {
+		if err := oldVersion.SetPrereleaseIdentifier(vbd.prereleaseIdentifier); err != nil {
+			return false, err
+		}
+	}
 	err := oldVersion.Increment(vbd.runArgs.VersionType, vbd.runArgs.PrereleaseType, vbd.runArgs.PrereleaseMetadata)
 	if err != nil {
 		return false, err
 	}
+	if vbd.prereleaseCommits > 0 {
+		if err := oldVersion.SetPrereleaseString(fmt.Sprintf("%s.%d", vbd.prereleaseIdentifier, vbd.prereleaseCommits)); err != nil {
+			return false, err
+		}
+	}
+	if oldVersion.IsPrerelease() {
+		if err := vbd.skipTaggedPrereleases(oldVersion); err != nil {
+			return false, err
+		}
+	}
 	vbd.versionStr = oldVersion.String()
 	if strings.Compare(oldVersionStr, vbd.versionStr) == 0 {
 		//no changes in version
@@ -421,15 +869,21 @@ func (vbd *versionBumpData) incrementAndCompareVersions(oldVersion *version.Vers
 	return false, nil
 }
 
+// awaitVersionGathering blocks until every file is read, and returns the error cancelling the replacements, if any
+func (b *Bump) awaitVersionGathering() error {
+	<-b.versionsGathered
+	return b.errVersionGathering
+}
+
 func (vbd *versionBumpData) runRegexReplacement(langSettings *langs.DefaultSettings, fileContent []string, lineNumber int, filepath string, oldVersionStr string) {
-	err := <-vbd.bump.errChanVersionGathering
-	if err != nil {
+	defer vbd.bump.WaitGroup.Done()
+
+	if err := vbd.bump.awaitVersionGathering(); err != nil {
 		return
 	}
 
-	vbd.bump.WaitGroup.Add(1)
-
 	vbd.bump.mutex.Lock()
+	defer vbd.bump.mutex.Unlock()
 
 	console.Language(langSettings.Name, vbd.runArgs.IsDryRun)
 
@@ -440,30 +894,55 @@ func (vbd *versionBumpData) runRegexReplacement(langSettings *langs.DefaultSetti
 		replacedLine := strings.ReplaceAll(line, oldVersionStr, vbd.versionStr)
 		fileContent[lineNumber] = strings.ReplaceAll(fileContent[lineNumber], line, replacedLine)
 		fileContent = append(fileContent, "")
-		if err := writeFile(vbd.bump.FS, filepath, strings.Join(fileContent, "\n")); err != nil {
+		if err := vbd.writeFile(filepath, strings.Join(fileContent, "\n")); err != nil {
 			vbd.bump.errChanPostProcessing <- errors.Wrapf(err, ErrStrFormattedWritingToFile, filepath)
 		}
 	}
 
 	console.VersionUpdateLine(oldVersionStr, vbd.versionStr, filepath, line)
+}
+
+func (vbd *versionBumpData) runLocatorReplacement(langSettings *langs.DefaultSettings, content []byte, locations [][]int, filepath string, oldVersionStr string) {
+	defer vbd.bump.WaitGroup.Done()
+
+	if err := vbd.bump.awaitVersionGathering(); err != nil {
+		return
+	}
+
+	vbd.bump.mutex.Lock()
+	defer vbd.bump.mutex.Unlock()
+
+	console.Language(langSettings.Name, vbd.runArgs.IsDryRun)
 
-	vbd.bump.mutex.Unlock()
+	newContent := make([]byte, 0, len(content))
+	last := 0
+	for _, location := range locations {
+		newContent = append(newContent, content[last:location[0]]...)
+		newContent = append(newContent, strings.Replace(string(content[location[0]:location[1]]), oldVersionStr, vbd.versionStr, 1)...)
+		last = location[1]
+	}
+	newContent = append(newContent, content[last:]...)
 
-	vbd.bump.WaitGroup.Done()
+	if !vbd.runArgs.IsDryRun {
+		if err := vbd.writeFile(filepath, string(newContent)); err != nil {
+			vbd.bump.errChanPostProcessing <- errors.Wrapf(err, ErrStrFormattedWritingToFile, filepath)
+		}
+	}
 
-	return
+	for _, location := range locations {
+		console.VersionUpdateLine(oldVersionStr, vbd.versionStr, filepath, lineAt(content, location[0]))
+	}
 }
 
 func (vbd *versionBumpData) runJsonFieldReplacement(langSettings *langs.DefaultSettings, fileContent []string, field string, filepath string, oldVersionStr string) {
+	defer vbd.bump.WaitGroup.Done()
 
-	err := <-vbd.bump.errChanVersionGathering
-	if err != nil {
+	if err := vbd.bump.awaitVersionGathering(); err != nil {
 		return
 	}
 
-	vbd.bump.WaitGroup.Add(1)
-
 	vbd.bump.mutex.Lock()
+	defer vbd.bump.mutex.Unlock()
 
 	console.Language(langSettings.Name, vbd.runArgs.IsDryRun)
 
@@ -475,7 +954,7 @@ func (vbd *versionBumpData) runJsonFieldReplacement(langSettings *langs.DefaultS
 				return
 			}
 
-			if err := writeFile(vbd.bump.FS, filepath, newContent); err != nil {
+			if err := vbd.writeFile(filepath, newContent); err != nil {
 				vbd.bump.errChanPostProcessing <- errors.Wrapf(err, ErrStrFormattedWritingToFile, filepath)
 				return
 			}
@@ -483,10 +962,6 @@ func (vbd *versionBumpData) runJsonFieldReplacement(langSettings *langs.DefaultS
 	}
 
 	console.VersionUpdateField(oldVersionStr, vbd.versionStr, filepath, field)
-
-	vbd.bump.mutex.Unlock()
-
-	vbd.bump.WaitGroup.Done()
 }
 
 func (vbd *versionBumpData) versionConfirmationPrompt(oldVersionStr string, file string) (bool, error) {
@@ -6,15 +6,23 @@ import (
 	"github.com/nidhhoggr/version-bump/langs/docker"
 	"github.com/nidhhoggr/version-bump/langs/golang"
 	"github.com/nidhhoggr/version-bump/langs/js"
+	"github.com/nidhhoggr/version-bump/langs/plaintext"
 	"path"
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/nidhhoggr/version-bump/bump"
+	"github.com/nidhhoggr/version-bump/changelog"
 	"github.com/nidhhoggr/version-bump/git"
 	"github.com/nidhhoggr/version-bump/mocks"
 	"github.com/nidhhoggr/version-bump/version"
@@ -59,6 +67,11 @@ func TestBump_New(t *testing.T) {
 					Enabled:     true,
 					Directories: []string{"."},
 				},
+				langs.Config{
+					Name:        plaintext.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+				},
 			},
 			ExpectedError: "",
 		},
@@ -107,6 +120,21 @@ directories = ['dir1','dir2']`,
 				},
 			},
 		},
+		"PlainText": {
+			ConfigFile: configFile{
+				Exists: true,
+				Content: `[plaintext]
+enabled = true
+directories = ['dir1']`,
+			},
+			ExpectedConfiguration: bump.Configuration{
+				langs.Config{
+					Name:        plaintext.Name,
+					Enabled:     true,
+					Directories: []string{"dir1"},
+				},
+			},
+		},
 		"Complex": {
 			ConfigFile: configFile{
 				Exists: true,
@@ -340,6 +368,142 @@ exclude_files = [ 'client/test.js' ]`,
 	}
 }
 
+func TestBump_NewWithPackages(t *testing.T) {
+	a := assert.New(t)
+
+	fs := afero.NewMemMapFs()
+	meta := memfs.New()
+	data := memfs.New()
+	a.Nil(git.Init(meta, data))
+
+	a.Nil(afero.WriteFile(fs, ".bump", []byte(`[[package]]
+name = "api"
+directories = [ 'services/api' ]
+tag = "api-v{{version}}"
+
+[package.go]
+enabled = true
+directories = [ '.', 'cmd' ]
+
+[package.docker]
+enabled = true
+images = [ 'ghcr.io/acme/api' ]
+
+[[package]]
+name = "web"
+directories = [ 'services/web' ]
+
+[dependents]
+update = true
+`), 0644))
+
+	b, err := bump.From(fs, meta, data, ".")
+	a.Nil(err)
+	a.Equal(bump.Configuration{}, b.Configuration)
+	a.Equal(langs.DependentsConfig{Update: true}, b.Dependents)
+	a.Equal([]bump.Package{
+		{
+			Name:        "api",
+			TagTemplate: "api-v{{version}}",
+			Directories: []string{"services/api"},
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        docker.Name,
+					Enabled:     true,
+					Directories: []string{"services/api"},
+					Images:      []string{"ghcr.io/acme/api"},
+				},
+				langs.Config{
+					Name:        golang.Name,
+					Enabled:     true,
+					Directories: []string{"services/api", "services/api/cmd"},
+				},
+			},
+		},
+		{
+			Name:        "web",
+			TagTemplate: "web-v{{version}}",
+			Directories: []string{"services/web"},
+			Configuration: bump.Configuration{
+				langs.Config{Name: docker.Name, Enabled: true, Directories: []string{"services/web"}},
+				langs.Config{Name: golang.Name, Enabled: true, Directories: []string{"services/web"}},
+				langs.Config{Name: js.Name, Enabled: true, Directories: []string{"services/web"}},
+				langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"services/web"}},
+			},
+		},
+	}, b.Packages)
+}
+
+func TestBump_NewWithGitTemplates(t *testing.T) {
+	type test struct {
+		Config                        string
+		ExpectedTagTemplate           string
+		ExpectedCommitMessageTemplate string
+		ExpectedTagMessageTemplate    string
+		ExpectedLightweightTags       bool
+		ExpectedAliasTagTemplates     []string
+		ExpectedErrorStr              string
+	}
+
+	suite := map[string]test{
+		"Templates": {
+			Config: `[git]
+commit_message = "release: v{{.Version}} [skip ci]"
+tag_name = "{{version}}"
+tag_message = "Release {{.Version}} from {{.OldVersion}}"
+`,
+			ExpectedTagTemplate:           "{{version}}",
+			ExpectedCommitMessageTemplate: "release: v{{.Version}} [skip ci]",
+			ExpectedTagMessageTemplate:    "Release {{.Version}} from {{.OldVersion}}",
+		},
+		"Lightweight Alias Tags": {
+			Config: `[git]
+lightweight_tags = true
+alias_tags = [ "v{{major}}", "v{{major}}.{{minor}}" ]
+`,
+			ExpectedLightweightTags:   true,
+			ExpectedAliasTagTemplates: []string{"v{{major}}", "v{{major}}.{{minor}}"},
+		},
+		"Invalid Alias Tag": {
+			Config:           "[git]\nalias_tags = [ \"v{{major\" ]\n",
+			ExpectedErrorStr: bump.ErrStrParsingConfigFile,
+		},
+		"Invalid Template": {
+			Config:           "[git]\ncommit_message = \"release: {{.Version\"\n",
+			ExpectedErrorStr: bump.ErrStrParsingConfigFile,
+		},
+		"Tag Name Without Version": {
+			Config:           "[git]\ntag_name = \"latest\"\n",
+			ExpectedErrorStr: fmt.Sprintf("%s: %s", bump.ErrStrParsingConfigFile, fmt.Sprintf(bump.ErrStrFormattedInvalidTagTemplate, "latest")),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		fs := afero.NewMemMapFs()
+		meta := memfs.New()
+		data := memfs.New()
+		a.Nil(git.Init(meta, data))
+		a.Nil(afero.WriteFile(fs, ".bump", []byte(test.Config), 0644))
+
+		b, err := bump.From(fs, meta, data, ".")
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+		a.Equal(test.ExpectedTagTemplate, b.Git.TagTemplate)
+		a.Equal(test.ExpectedCommitMessageTemplate, b.Git.CommitMessageTemplate)
+		a.Equal(test.ExpectedTagMessageTemplate, b.Git.TagMessageTemplate)
+		a.Equal(test.ExpectedLightweightTags, b.Git.LightweightTags)
+		a.Equal(test.ExpectedAliasTagTemplates, b.Git.AliasTagTemplates)
+	}
+}
+
 type file struct {
 	Name                string
 	ExpectedToBeChanged bool
@@ -352,6 +516,7 @@ type allFiles struct {
 	Docker     fileMap
 	Go         fileMap
 	JavaScript fileMap
+	PlainText  fileMap
 	Generic    fileMap
 }
 
@@ -647,6 +812,62 @@ ENTRYPOINT [ "/app" ]`,
 			VersionType:    version.Major,
 			PrereleaseType: version.NotAPrerelease,
 		},
+		"Docker - ARG Default": {
+			Version: "1.3.0",
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        docker.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+				},
+			},
+			Files: allFiles{
+				Docker: map[string][]file{
+					".": {
+						{
+							Name:                "Dockerfile",
+							ExpectedToBeChanged: true,
+							Content: `ARG GO_VERSION=1.22.1
+FROM golang:${GO_VERSION}
+ARG VERSION=1.2.3
+ENV APP_VERSION=${VERSION}`,
+						},
+					},
+				},
+			},
+			VersionType:    version.Minor,
+			PrereleaseType: version.NotAPrerelease,
+		},
+		"Docker - Compose Image Tags": {
+			Version: "1.2.4",
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        docker.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+					Images:      []string{"ghcr.io/acme/api"},
+				},
+			},
+			Files: allFiles{
+				Docker: map[string][]file{
+					".": {
+						{
+							Name:                "docker-compose.prod.yml",
+							ExpectedToBeChanged: true,
+							Content: `services:
+  api:
+    image: ghcr.io/acme/api:1.2.3
+  worker:
+    image: ghcr.io/acme/api:1.2.3
+  db:
+    image: postgres:1.2.3`,
+						},
+					},
+				},
+			},
+			VersionType:    version.Patch,
+			PrereleaseType: version.NotAPrerelease,
+		},
 		"Go - Single Constant": {
 			Version: "1.3.0",
 			Configuration: bump.Configuration{
@@ -743,6 +964,100 @@ func main() {
 			VersionType:    version.Major,
 			PrereleaseType: version.NotAPrerelease,
 		},
+		"Go - Function Scoped Version": {
+			Version: "1.3.0",
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        golang.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+				},
+			},
+			Files: allFiles{
+				Go: map[string][]file{
+					".": {
+						{
+							Name:                "version.go",
+							ExpectedToBeChanged: true,
+							Content: `package main
+
+const Version = "1.2.3"`,
+						},
+						{
+							Name:                "main.go",
+							ExpectedToBeChanged: false,
+							Content: `package main
+
+func main() {
+	var (
+		version string = "0.9.0"
+	)
+	println(version)
+}`,
+						},
+					},
+				},
+			},
+			VersionType:    version.Minor,
+			PrereleaseType: version.NotAPrerelease,
+		},
+		"Go - Custom Regex": {
+			Version: "1.3.0",
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        golang.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+					Regex:       []string{`^// Release (?P<version>{{SEMVER_REGEX}})$`},
+				},
+			},
+			Files: allFiles{
+				Go: map[string][]file{
+					".": {
+						{
+							Name:                "main.go",
+							ExpectedToBeChanged: true,
+							Content: `package main
+
+// Release 1.2.3
+func main() {}`,
+						},
+					},
+				},
+			},
+			VersionType:    version.Minor,
+			PrereleaseType: version.NotAPrerelease,
+		},
+		"Go - Grouped Variables": {
+			Version: "1.3.0",
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        golang.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+					Identifiers: []string{"AppVersion"},
+				},
+			},
+			Files: allFiles{
+				Go: map[string][]file{
+					".": {
+						{
+							Name:                "version.go",
+							ExpectedToBeChanged: true,
+							Content: `package main
+
+var (
+	Name       = "app"
+	Version    = "0.0.1"
+	AppVersion = ` + "`1.2.3`" + `
+)`,
+						},
+					},
+				},
+			},
+			VersionType:    version.Minor,
+			PrereleaseType: version.NotAPrerelease,
+		},
 		"JavaScript - Multiple Constants": {
 			Version: "2.0.0",
 			Configuration: bump.Configuration{
@@ -821,6 +1136,58 @@ func main() {
 			VersionType:    version.Major,
 			PrereleaseType: version.NotAPrerelease,
 		},
+		"PlainText - VERSION File": {
+			Version: "1.3.0",
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        plaintext.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+				},
+			},
+			Files: allFiles{
+				PlainText: map[string][]file{
+					".": {
+						{
+							Name:                "VERSION",
+							ExpectedToBeChanged: true,
+							Content: `1.2.3
+`,
+						},
+					},
+				},
+			},
+			VersionType:    version.Minor,
+			PrereleaseType: version.NotAPrerelease,
+		},
+		"PlainText - Extra Content Fails": {
+			Version: "1.3.0",
+			Configuration: bump.Configuration{
+				langs.Config{
+					Name:        plaintext.Name,
+					Enabled:     true,
+					Directories: []string{"."},
+				},
+			},
+			Files: allFiles{
+				PlainText: map[string][]file{
+					".": {
+						{
+							Name:                "version.txt",
+							ExpectedToBeChanged: false,
+							Content: `1.2.3
+built from main`,
+						},
+					},
+				},
+			},
+			VersionType:    version.Minor,
+			PrereleaseType: version.NotAPrerelease,
+			ExpectedErrorContains: []string{
+				fmt.Sprintf(bump.ErrStrFormattedIncrementingInLangProject, plaintext.Name),
+				fmt.Sprintf(bump.ErrStrFormattedUnexpectedContentInVersionFile, "version.txt", 2, "built from main"),
+			},
+		},
 		"Generic - Single Constant": {
 			Version: "0.2.4-alpha.2",
 			Configuration: bump.Configuration{
@@ -1272,54 +1639,2151 @@ const Version string = "1.2.3"`,
 	}
 }
 
-func TestBump_WithVanillaFsRepoDoesntExist(t *testing.T) {
-	a := assert.New(t)
-	_, err := bump.New(".")
-	a.ErrorContains(err, fmt.Sprintf("%s: repository does not exist", git.ErrStrOpeningRepo))
-}
-
-func TestBump_BrokenBumpFile(t *testing.T) {
-	a := assert.New(t)
-	fs := afero.NewMemMapFs()
-	meta := memfs.New()
-	data := memfs.New()
-	_ = git.Init(meta, data)
-	f, err := fs.Create(".bump")
-	_, err = f.WriteString("brokenbump-contents")
-	_, err = bump.From(fs, meta, data, ".")
-	a.ErrorContains(err, bump.ErrStrParsingConfigFile)
-}
-
-func TestBump_ConfirmationError(t *testing.T) {
+func TestBump_DockerImageTagsContent(t *testing.T) {
 	a := assert.New(t)
 
-	testSuite := testSuites["Go - Single Constant #2"]
+	testSuite := testSuites["Docker - Compose Image Tags"]
 
-	_, err := runBumpTest(t, testSuite, &bump.RunArgs{
+	b, err := runBumpTest(t, testSuite, &bump.RunArgs{
 		VersionType:    testSuite.VersionType,
 		PrereleaseType: testSuite.PrereleaseType,
-		ConfirmationPrompt: func(_ string, _ string, _ string) (bool, error) {
-			return true, fmt.Errorf("confirmation_error")
-		},
 	})
-	a.ErrorContains(err, fmt.Sprintf("%s: confirmation_error", bump.ErrStrDuringConfirmationPrompt))
+	a.Nil(err)
+
+	content, err := afero.ReadFile(b.FS, "docker-compose.prod.yml")
+	a.Nil(err)
+	a.Equal(`services:
+  api:
+    image: ghcr.io/acme/api:1.2.4
+  worker:
+    image: ghcr.io/acme/api:1.2.4
+  db:
+    image: postgres:1.2.3`, string(content))
 }
 
-func TestBump_ConfirmationDenied(t *testing.T) {
+func TestBump_GoGroupedVariablesContent(t *testing.T) {
 	a := assert.New(t)
 
-	testSuite := testSuites["Go - Single Constant #2"]
+	testSuite := testSuites["Go - Grouped Variables"]
 
-	_, err := runBumpTest(t, testSuite, &bump.RunArgs{
+	b, err := runBumpTest(t, testSuite, &bump.RunArgs{
 		VersionType:    testSuite.VersionType,
 		PrereleaseType: testSuite.PrereleaseType,
-		ConfirmationPrompt: func(_ string, _ string, _ string) (bool, error) {
-			return false, nil
-		},
 	})
-	//currently we continue through the loop instead of returning an error
 	a.Nil(err)
-}
+
+	content, err := afero.ReadFile(b.FS, "version.go")
+	a.Nil(err)
+	a.Equal(`package main
+
+var (
+	Name       = "app"
+	Version    = "0.0.1"
+	AppVersion = `+"`1.3.0`"+`
+)`, string(content))
+}
+
+func TestBump_MigrateGoModule(t *testing.T) {
+	a := assert.New(t)
+
+	m1 := new(mocks.Repository)
+	m1.On("Tags").Return(noTags, nil)
+	m2 := new(mocks.Worktree)
+
+	gitConfig := new(config.Config)
+	gitConfig.User.Name = git.Username
+	gitConfig.User.Email = git.Email
+
+	b := bump.Bump{
+		FS: afero.NewMemMapFs(),
+		Git: &git.Instance{
+			Config:     gitConfig,
+			Repository: m1,
+			Worktree:   m2,
+		},
+		Configuration: bump.Configuration{
+			langs.Config{
+				Name:          golang.Name,
+				Enabled:       true,
+				Directories:   []string{"."},
+				MigrateModule: true,
+			},
+		},
+		WaitGroup: new(sync.WaitGroup),
+	}
+
+	files := map[string]string{
+		"go.mod": "module example.com/acme\n\ngo 1.23\n",
+		"main.go": `package main
+
+import "example.com/acme/internal/lib"
+
+const Version = "1.4.2"
+
+func main() {
+	lib.Run()
+}
+`,
+		"internal/lib/lib.go": "package lib\n\nfunc Run() {}\n",
+		"tools/go.mod":        "module example.com/acme/tools\n",
+		"tools/tools.go":      "package tools\n\nimport _ \"example.com/acme/internal/lib\"\n",
+	}
+	for name, content := range files {
+		a.Nil(afero.WriteFile(b.FS, name, []byte(content), 0644))
+	}
+
+	m2.On("Add", "main.go").Return(nil, nil).Once()
+	m2.On("Add", "go.mod").Return(nil, nil).Once()
+	hash := plumbing.NewHash("abc")
+	m2.On("Status").Return(gogit.Status{}, nil).Once()
+	m2.On("Commit", "2.0.0", mock.AnythingOfType("*git.CommitOptions")).Return(hash, nil).Once()
+	m1.On("CreateTag", "v2.0.0", hash, mock.AnythingOfType("*git.CreateTagOptions")).Return(nil, nil).Once()
+
+	err := b.Bump(&bump.RunArgs{
+		VersionType:    version.Major,
+		PrereleaseType: version.NotAPrerelease,
+	})
+	a.Nil(err)
+	m1.AssertExpectations(t)
+	m2.AssertExpectations(t)
+
+	content, _ := afero.ReadFile(b.FS, "go.mod")
+	a.Equal("module example.com/acme/v2\n\ngo 1.23\n", string(content))
+
+	content, _ = afero.ReadFile(b.FS, "main.go")
+	a.Equal(`package main
+
+import "example.com/acme/v2/internal/lib"
+
+const Version = "2.0.0"
+
+func main() {
+	lib.Run()
+}
+`, string(content))
+
+	content, _ = afero.ReadFile(b.FS, "tools/tools.go")
+	a.Equal("package tools\n\nimport _ \"example.com/acme/internal/lib\"\n", string(content))
+}
+
+func TestBump_MigrateGoModuleOnce(t *testing.T) {
+	type test struct {
+		Configuration   bump.Configuration
+		Files           map[string]string
+		VersionType     version.Type
+		ExpectedVersion string
+		ExpectedAdded   []string
+		ExpectedFiles   map[string]string
+	}
+
+	migrated := langs.Config{Name: golang.Name, Enabled: true, MigrateModule: true}
+	root, lib := migrated, migrated
+	root.Directories = []string{"."}
+	lib.Directories = []string{"internal/lib"}
+
+	suite := map[string]test{
+		"Two Entries Of One Module": {
+			Configuration: bump.Configuration{root, lib},
+			Files: map[string]string{
+				"go.mod":              "module example.com/acme\n\ngo 1.23\n",
+				"main.go":             "package main\n\nimport \"example.com/acme/internal/lib\"\n\nconst Version = \"1.4.2\"\n\nfunc main() {\n\tlib.Run()\n}\n",
+				"internal/lib/lib.go": "package lib\n\nconst Version = \"1.4.2\"\n\nfunc Run() {}\n",
+			},
+			VersionType:     version.Major,
+			ExpectedVersion: "2.0.0",
+			ExpectedAdded:   []string{"main.go", "internal/lib/lib.go", "go.mod"},
+			ExpectedFiles: map[string]string{
+				"go.mod":              "module example.com/acme/v2\n\ngo 1.23\n",
+				"main.go":             "package main\n\nimport \"example.com/acme/v2/internal/lib\"\n\nconst Version = \"2.0.0\"\n\nfunc main() {\n\tlib.Run()\n}\n",
+				"internal/lib/lib.go": "package lib\n\nconst Version = \"2.0.0\"\n\nfunc Run() {}\n",
+			},
+		},
+		"Same Major Version": {
+			Configuration: bump.Configuration{root},
+			Files: map[string]string{
+				"go.mod":  "module example.com/acme\n\ngo 1.23\n",
+				"main.go": "package main\n\nconst Version = \"2.3.0\"\n",
+			},
+			VersionType:     version.Minor,
+			ExpectedVersion: "2.4.0",
+			ExpectedAdded:   []string{"main.go"},
+			ExpectedFiles: map[string]string{
+				"go.mod":  "module example.com/acme\n\ngo 1.23\n",
+				"main.go": "package main\n\nconst Version = \"2.4.0\"\n",
+			},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		m1 := new(mocks.Repository)
+		m1.On("Tags").Return(noTags, nil)
+		m2 := new(mocks.Worktree)
+
+		gitConfig := new(config.Config)
+		gitConfig.User.Name = git.Username
+		gitConfig.User.Email = git.Email
+
+		b := bump.Bump{
+			FS: afero.NewMemMapFs(),
+			Git: &git.Instance{
+				Config:     gitConfig,
+				Repository: m1,
+				Worktree:   m2,
+			},
+			Configuration: test.Configuration,
+			WaitGroup:     new(sync.WaitGroup),
+		}
+
+		a := assert.New(t)
+		for name, content := range test.Files {
+			a.Nil(afero.WriteFile(b.FS, name, []byte(content), 0644))
+		}
+
+		for _, name := range test.ExpectedAdded {
+			m2.On("Add", name).Return(nil, nil).Once()
+		}
+		hash := plumbing.NewHash("abc")
+		m2.On("Status").Return(gogit.Status{}, nil).Once()
+		m2.On("Commit", test.ExpectedVersion, mock.AnythingOfType("*git.CommitOptions")).Return(hash, nil).Once()
+		m1.On("CreateTag", fmt.Sprintf("v%v", test.ExpectedVersion), hash, mock.AnythingOfType("*git.CreateTagOptions")).Return(nil, nil).Once()
+
+		err := b.Bump(&bump.RunArgs{
+			VersionType:    test.VersionType,
+			PrereleaseType: version.NotAPrerelease,
+		})
+		a.Nil(err)
+		m1.AssertExpectations(t)
+		m2.AssertExpectations(t)
+
+		for name, expected := range test.ExpectedFiles {
+			content, _ := afero.ReadFile(b.FS, name)
+			a.Equal(expected, string(content), name)
+		}
+	}
+}
+
+func TestBump_MigrateGoModuleRollback(t *testing.T) {
+	a := assert.New(t)
+
+	m1 := new(mocks.Repository)
+	m1.On("Tags").Return(noTags, nil)
+	m2 := new(mocks.Worktree)
+
+	gitConfig := new(config.Config)
+	gitConfig.User.Name = git.Username
+	gitConfig.User.Email = git.Email
+
+	b := bump.Bump{
+		FS: afero.NewMemMapFs(),
+		Git: &git.Instance{
+			Config:     gitConfig,
+			Repository: m1,
+			Worktree:   m2,
+		},
+		Configuration: bump.Configuration{
+			langs.Config{
+				Name:          golang.Name,
+				Enabled:       true,
+				Directories:   []string{"."},
+				MigrateModule: true,
+			},
+		},
+		WaitGroup: new(sync.WaitGroup),
+	}
+
+	// the requirements of the root module cannot be parsed, so updating it fails once the released module is migrated
+	files := map[string]string{
+		"go.work":           "go 1.23\n\nuse (\n\t.\n\t./tools/cli\n)\n",
+		"go.mod":            "module example.com/acme\n\ngo 1.23\n\nrequire example.com/acme/tools/cli\n",
+		"tools/cli/go.mod":  "module example.com/acme/tools/cli\n\ngo 1.23\n",
+		"tools/cli/main.go": "package main\n\nimport \"example.com/acme/tools/cli/internal/cmd\"\n\nconst Version = \"1.3.0\"\n\nfunc main() {\n\tcmd.Run()\n}\n",
+	}
+	for name, content := range files {
+		a.Nil(afero.WriteFile(b.FS, name, []byte(content), 0644))
+	}
+
+	err := b.Bump(&bump.RunArgs{
+		VersionType:    version.Major,
+		PrereleaseType: version.NotAPrerelease,
+		GoModule:       "tools/cli",
+	})
+	a.ErrorContains(err, fmt.Sprintf(bump.ErrStrFormattedUpdatingGoModuleDependent, "."))
+	m2.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+
+	for name, content := range files {
+		actual, _ := afero.ReadFile(b.FS, name)
+		a.Equal(content, string(actual), name)
+	}
+}
+
+func TestBump_GoModuleRelease(t *testing.T) {
+	a := assert.New(t)
+
+	m1 := new(mocks.Repository)
+	m1.On("Tags").Return(noTags, nil)
+	m2 := new(mocks.Worktree)
+
+	gitConfig := new(config.Config)
+	gitConfig.User.Name = git.Username
+	gitConfig.User.Email = git.Email
+
+	b := bump.Bump{
+		FS: afero.NewMemMapFs(),
+		Git: &git.Instance{
+			Config:     gitConfig,
+			Repository: m1,
+			Worktree:   m2,
+		},
+		Configuration: bump.Configuration{
+			langs.Config{
+				Name:        golang.Name,
+				Enabled:     true,
+				Directories: []string{"."},
+			},
+		},
+		WaitGroup: new(sync.WaitGroup),
+	}
+
+	files := map[string]string{
+		"go.work": "go 1.23\n\nuse (\n\t.\n\t./tools/cli\n)\n",
+		"go.mod":  "module example.com/acme\n\ngo 1.23\n\nrequire example.com/acme/tools/cli v1.3.0\n",
+		"main.go": `package main
+
+const Version = "0.9.0"
+`,
+		"tools/cli/go.mod": "module example.com/acme/tools/cli\n\ngo 1.23\n",
+		"tools/cli/main.go": `package main
+
+const Version = "1.3.0"
+`,
+	}
+	for name, content := range files {
+		a.Nil(afero.WriteFile(b.FS, name, []byte(content), 0644))
+	}
+
+	m2.On("Add", "tools/cli/main.go").Return(nil, nil).Once()
+	m2.On("Add", "go.mod").Return(nil, nil).Once()
+	hash := plumbing.NewHash("abc")
+	m2.On("Status").Return(gogit.Status{}, nil).Once()
+	m2.On("Commit", "1.4.0", mock.AnythingOfType("*git.CommitOptions")).Return(hash, nil).Once()
+	m1.On("CreateTag", "tools/cli/v1.4.0", hash, mock.AnythingOfType("*git.CreateTagOptions")).Return(nil, nil).Once()
+
+	err := b.Bump(&bump.RunArgs{
+		VersionType:    version.Minor,
+		PrereleaseType: version.NotAPrerelease,
+		GoModule:       "./tools/cli",
+	})
+	a.Nil(err)
+	m1.AssertExpectations(t)
+	m2.AssertExpectations(t)
+
+	// the module scope is not left on the Bump for its later runs
+	a.Equal("", b.Git.TagTemplate)
+	a.Equal(bump.Configuration{langs.Config{Name: golang.Name, Enabled: true, Directories: []string{"."}}}, b.Configuration)
+
+	content, _ := afero.ReadFile(b.FS, "go.mod")
+	a.Equal("module example.com/acme\n\ngo 1.23\n\nrequire example.com/acme/tools/cli v1.4.0\n", string(content))
+
+	content, _ = afero.ReadFile(b.FS, "main.go")
+	a.Equal("package main\n\nconst Version = \"0.9.0\"\n", string(content))
+
+	err = b.Bump(&bump.RunArgs{
+		VersionType: version.Minor,
+		GoModule:    "tools/missing",
+	})
+	a.EqualError(err, fmt.Sprintf(bump.ErrStrFormattedGoModuleNotFound, "tools/missing"))
+}
+
+func TestBump_Package(t *testing.T) {
+	a := assert.New(t)
+
+	m1 := new(mocks.Repository)
+	m1.On("Tags").Return(noTags, nil)
+	m2 := new(mocks.Worktree)
+
+	gitConfig := new(config.Config)
+	gitConfig.User.Name = git.Username
+	gitConfig.User.Email = git.Email
+
+	b := bump.Bump{
+		FS: afero.NewMemMapFs(),
+		Git: &git.Instance{
+			Config:     gitConfig,
+			Repository: m1,
+			Worktree:   m2,
+		},
+		Packages: []bump.Package{
+			{
+				Name:        "api",
+				TagTemplate: "api-v{{version}}",
+				Configuration: bump.Configuration{
+					langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"services/api"}},
+				},
+			},
+			{
+				Name:        "web",
+				TagTemplate: "web-v{{version}}",
+				Configuration: bump.Configuration{
+					langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"services/web"}},
+				},
+			},
+		},
+		WaitGroup: new(sync.WaitGroup),
+	}
+
+	a.Nil(afero.WriteFile(b.FS, "services/api/VERSION", []byte("1.2.3\n"), 0644))
+	a.Nil(afero.WriteFile(b.FS, "services/web/VERSION", []byte("4.0.0\n"), 0644))
+
+	m2.On("Add", "services/web/VERSION").Return(nil, nil).Once()
+	hash := plumbing.NewHash("abc")
+	m2.On("Status").Return(gogit.Status{}, nil).Once()
+	m2.On("Commit", "4.1.0", mock.AnythingOfType("*git.CommitOptions")).Return(hash, nil).Once()
+	m1.On("CreateTag", "web-v4.1.0", hash, mock.AnythingOfType("*git.CreateTagOptions")).Return(nil, nil).Once()
+
+	err := b.Bump(&bump.RunArgs{
+		VersionType: version.Minor,
+		Package:     "web",
+	})
+	a.Nil(err)
+	m1.AssertExpectations(t)
+	m2.AssertExpectations(t)
+
+	// the package scope is not left on the Bump for its later runs
+	a.Equal("", b.Git.TagTemplate)
+	a.Nil(b.Configuration)
+
+	content, _ := afero.ReadFile(b.FS, "services/api/VERSION")
+	a.Equal("1.2.3\n", string(content))
+
+	err = b.Bump(&bump.RunArgs{
+		VersionType: version.Minor,
+		Package:     "missing",
+	})
+	a.EqualError(err, fmt.Sprintf(bump.ErrStrFormattedPackageNotFound, "missing"))
+}
+
+func TestBump_Dependents(t *testing.T) {
+	type test struct {
+		Cascade         bool
+		ExpectedTags    []string
+		ExpectedContent map[string]string
+	}
+
+	suite := map[string]test{
+		"Update Constraints": {
+			ExpectedTags: []string{"api-v1.3.0"},
+			ExpectedContent: map[string]string{
+				"services/web/package.json": `{
+  "name": "@acme/web",
+  "version": "4.0.0",
+  "dependencies": {
+    "@acme/api": "^1.3.0"
+  }
+}
+`,
+				"services/worker/go.mod": `module example.com/acme/worker
+
+go 1.23
+
+require example.com/acme/api v1.3.0
+
+replace example.com/acme/api => ../api
+`,
+				"services/worker/VERSION": "2.0.0\n",
+				"tools/Cargo.toml": `[package]
+name = "tools"
+
+[dependencies]
+api = { path = "../services/api", version = "~1.3.0" }
+`,
+				"tools/package.json": `{
+  "name": "@acme/tools",
+  "version": "0.1.0",
+  "devDependencies": {
+    "@acme/web": "~4.0.0"
+  }
+}
+`,
+			},
+		},
+		"Cascade Patch Releases": {
+			Cascade:      true,
+			ExpectedTags: []string{"api-v1.3.0", "web-v4.0.1", "tools-v0.1.1", "worker-v2.0.1"},
+			ExpectedContent: map[string]string{
+				"services/web/package.json": `{
+  "name": "@acme/web",
+  "version": "4.0.1",
+  "dependencies": {
+    "@acme/api": "^1.3.0"
+  }
+}`,
+				"services/worker/VERSION": "2.0.1\n",
+				"tools/package.json": `{
+  "name": "@acme/tools",
+  "version": "0.1.1",
+  "devDependencies": {
+    "@acme/web": "~4.0.1"
+  }
+}`,
+			},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a := assert.New(t)
+		m1 := new(mocks.Repository)
+		m1.On("Tags").Return(noTags, nil)
+		m2 := new(mocks.Worktree)
+
+		gitConfig := new(config.Config)
+		gitConfig.User.Name = git.Username
+		gitConfig.User.Email = git.Email
+
+		b := bump.Bump{
+			FS: afero.NewMemMapFs(),
+			Git: &git.Instance{
+				Config:     gitConfig,
+				Repository: m1,
+				Worktree:   m2,
+			},
+			Packages: []bump.Package{
+				{
+					Name:        "api",
+					TagTemplate: "api-v{{version}}",
+					Directories: []string{"services/api"},
+					Configuration: bump.Configuration{
+						langs.Config{Name: js.Name, Enabled: true, Directories: []string{"services/api"}},
+					},
+				},
+				{
+					Name:        "web",
+					TagTemplate: "web-v{{version}}",
+					Directories: []string{"services/web"},
+					Configuration: bump.Configuration{
+						langs.Config{Name: js.Name, Enabled: true, Directories: []string{"services/web"}},
+					},
+				},
+				{
+					Name:        "worker",
+					TagTemplate: "worker-v{{version}}",
+					Directories: []string{"services/worker"},
+					Configuration: bump.Configuration{
+						langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"services/worker"}},
+					},
+				},
+				{
+					Name:        "tools",
+					TagTemplate: "tools-v{{version}}",
+					Directories: []string{"tools"},
+					Configuration: bump.Configuration{
+						langs.Config{Name: js.Name, Enabled: true, Directories: []string{"tools"}},
+					},
+				},
+			},
+			Dependents: langs.DependentsConfig{Update: true, Cascade: test.Cascade},
+			WaitGroup:  new(sync.WaitGroup),
+		}
+
+		fixtures := map[string]string{
+			"services/api/package.json": `{
+  "name": "@acme/api",
+  "version": "1.2.3"
+}
+`,
+			"services/api/go.mod": "module example.com/acme/api\n\ngo 1.23\n",
+			"services/api/Cargo.toml": `[package]
+name = "api"
+version = "1.2.3"
+`,
+			"services/web/package.json": `{
+  "name": "@acme/web",
+  "version": "4.0.0",
+  "dependencies": {
+    "@acme/api": "^1.2.3"
+  }
+}
+`,
+			"services/worker/go.mod": `module example.com/acme/worker
+
+go 1.23
+
+require example.com/acme/api v1.2.3
+
+replace example.com/acme/api => ../api
+`,
+			"services/worker/VERSION": "2.0.0\n",
+			"tools/Cargo.toml": `[package]
+name = "tools"
+
+[dependencies]
+api = { path = "../services/api", version = "~1.2.3" }
+`,
+			"tools/package.json": `{
+  "name": "@acme/tools",
+  "version": "0.1.0",
+  "devDependencies": {
+    "@acme/web": "~4.0.0"
+  }
+}
+`,
+		}
+		for f, content := range fixtures {
+			a.Nil(afero.WriteFile(b.FS, f, []byte(content), 0644))
+		}
+
+		hash := plumbing.NewHash("abc")
+		m2.On("Add", mock.AnythingOfType("string")).Return(nil, nil)
+		m2.On("Status").Return(gogit.Status{}, nil).Once()
+		m2.On("Commit", "1.3.0", mock.AnythingOfType("*git.CommitOptions")).Return(hash, nil).Once()
+		for _, tag := range test.ExpectedTags {
+			m1.On("CreateTag", tag, hash, mock.AnythingOfType("*git.CreateTagOptions")).Return(nil, nil).Once()
+		}
+
+		err := b.Bump(&bump.RunArgs{
+			VersionType: version.Minor,
+			Package:     "api",
+		})
+		a.Nil(err)
+		m1.AssertExpectations(t)
+		m2.AssertExpectations(t)
+
+		for f, expected := range test.ExpectedContent {
+			content, _ := afero.ReadFile(b.FS, f)
+			a.Equal(expected, string(content), f)
+			if expected != fixtures[f] {
+				m2.AssertCalled(t, "Add", f)
+			}
+		}
+	}
+}
+
+func TestBump_GoModuleDependents(t *testing.T) {
+	type test struct {
+		TagTemplate     string
+		VersionType     version.Type
+		ExpectedVersion string
+		ExpectedError   string
+	}
+
+	suite := map[string]test{
+		"Tag Of The Module Directory": {
+			TagTemplate:     "tools/cli/v{{version}}",
+			VersionType:     version.Minor,
+			ExpectedVersion: "1.4.0",
+		},
+		"Tag Go Cannot Resolve": {
+			TagTemplate:   "cli-v{{version}}",
+			VersionType:   version.Minor,
+			ExpectedError: fmt.Sprintf(bump.ErrStrFormattedUnresolvableGoTag, "cli-v1.4.0", "example.com/acme/tools/cli", "tools/cli/v1.4.0"),
+		},
+		"Major Version Without The Module Path": {
+			TagTemplate:   "tools/cli/v{{version}}",
+			VersionType:   version.Major,
+			ExpectedError: fmt.Sprintf(bump.ErrStrFormattedGoModuleWithoutMajorPath, "example.com/acme/tools/cli", "v2.0.0", "example.com/acme/tools/cli/v2"),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a := assert.New(t)
+		m1 := new(mocks.Repository)
+		m1.On("Tags").Return(noTags, nil)
+		m2 := new(mocks.Worktree)
+
+		gitConfig := new(config.Config)
+		gitConfig.User.Name = git.Username
+		gitConfig.User.Email = git.Email
+
+		b := bump.Bump{
+			FS: afero.NewMemMapFs(),
+			Git: &git.Instance{
+				Config:     gitConfig,
+				Repository: m1,
+				Worktree:   m2,
+			},
+			Packages: []bump.Package{
+				{
+					Name:        "cli",
+					TagTemplate: test.TagTemplate,
+					Directories: []string{"tools/cli"},
+					Configuration: bump.Configuration{
+						langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"tools/cli"}},
+					},
+				},
+				{
+					Name:        "server",
+					TagTemplate: "server/v{{version}}",
+					Directories: []string{"server"},
+					Configuration: bump.Configuration{
+						langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"server"}},
+					},
+				},
+			},
+			Dependents: langs.DependentsConfig{Update: true},
+			WaitGroup:  new(sync.WaitGroup),
+		}
+
+		files := map[string]string{
+			"tools/cli/go.mod":  "module example.com/acme/tools/cli\n\ngo 1.23\n",
+			"tools/cli/VERSION": "1.3.0\n",
+			"server/go.mod":     "module example.com/acme/server\n\ngo 1.23\n\nrequire example.com/acme/tools/cli v1.3.0\n",
+			"server/VERSION":    "0.2.0\n",
+		}
+		for f, content := range files {
+			a.Nil(afero.WriteFile(b.FS, f, []byte(content), 0644))
+		}
+
+		hash := plumbing.NewHash("abc")
+		if test.ExpectedError == "" {
+			m2.On("Add", mock.AnythingOfType("string")).Return(nil, nil)
+			m2.On("Status").Return(gogit.Status{}, nil).Once()
+			m2.On("Commit", test.ExpectedVersion, mock.AnythingOfType("*git.CommitOptions")).Return(hash, nil).Once()
+			m1.On("CreateTag", git.TagName(test.TagTemplate, test.ExpectedVersion), hash, mock.AnythingOfType("*git.CreateTagOptions")).Return(nil, nil).Once()
+		}
+
+		err := b.Bump(&bump.RunArgs{
+			VersionType: test.VersionType,
+			Package:     "cli",
+		})
+		m1.AssertExpectations(t)
+		m2.AssertExpectations(t)
+
+		if test.ExpectedError != "" {
+			a.ErrorContains(err, test.ExpectedError)
+			m2.AssertNotCalled(t, "Commit", mock.Anything, mock.Anything)
+			for f, content := range files {
+				actual, _ := afero.ReadFile(b.FS, f)
+				a.Equal(content, string(actual), f)
+			}
+		} else {
+			a.Nil(err)
+			content, _ := afero.ReadFile(b.FS, "server/go.mod")
+			a.Equal(fmt.Sprintf("module example.com/acme/server\n\ngo 1.23\n\nrequire example.com/acme/tools/cli v%v\n", test.ExpectedVersion), string(content))
+			m2.AssertCalled(t, "Add", "server/go.mod")
+		}
+	}
+}
+
+// newTaggedRepository commits files to a repository in a temporary directory and tags the commit with tagName.
+// The returned filesystem is the worktree of the repository.
+func newTaggedRepository(t *testing.T, files map[string]string, tagName string) (*git.Instance, afero.Fs) {
+	a := assert.New(t)
+
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	a.Nil(err)
+	wt, err := repo.Worktree()
+	a.Nil(err)
+
+	gitConfig := new(config.Config)
+	gitConfig.User.Name = git.Username
+	gitConfig.User.Email = git.Email
+
+	gi := &git.Instance{
+		Config:     gitConfig,
+		Repository: repo,
+		Worktree:   wt,
+	}
+
+	hash := commitFiles(t, gi, "1.2.0", files)
+	if tagName != "" {
+		_, err = repo.CreateTag(tagName, hash, &gogit.CreateTagOptions{
+			Tagger:  &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+			Message: tagName,
+		})
+		a.Nil(err)
+	}
+
+	return gi, afero.NewBasePathFs(afero.NewOsFs(), dir)
+}
+
+// commitFiles commits files to the repository of gi
+func commitFiles(t *testing.T, gi *git.Instance, message string, files map[string]string) plumbing.Hash {
+	a := assert.New(t)
+	wt := gi.Worktree.(*gogit.Worktree)
+	for name, content := range files {
+		a.Nil(util.WriteFile(wt.Filesystem, name, []byte(content), 0644))
+		_, err := wt.Add(name)
+		a.Nil(err)
+	}
+	hash, err := wt.Commit(message, &gogit.CommitOptions{
+		Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+	})
+	a.Nil(err)
+	return hash
+}
+
+const apiBaseline = `package acme
+
+const Version = "1.2.0"
+
+func Get(path string) error {
+	return nil
+}
+`
+
+func TestBump_Suggest(t *testing.T) {
+	type test struct {
+		TagName          string
+		TagVersion       string
+		NewContent       string
+		ExpectedType     version.Type
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Unchanged API": {
+			TagName:      "v1.2.0",
+			NewContent:   apiBaseline,
+			ExpectedType: version.Patch,
+		},
+		"Added Function": {
+			TagName:      "v1.2.0",
+			NewContent:   apiBaseline + "\nfunc Put(path string) error {\n\treturn nil\n}\n",
+			ExpectedType: version.Minor,
+		},
+		"Removed Function": {
+			TagName:      "v1.2.0",
+			NewContent:   "package acme\n\nconst Version = \"1.2.0\"\n",
+			ExpectedType: version.Major,
+		},
+		"Removed Function In Initial Development": {
+			TagName:      "v0.4.0",
+			NewContent:   "package acme\n\nconst Version = \"1.2.0\"\n",
+			ExpectedType: version.Minor,
+		},
+		"Never Released": {
+			NewContent:       apiBaseline,
+			ExpectedErrorStr: bump.ErrStrNoReleaseTag,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		gi, fs := newTaggedRepository(t, map[string]string{
+			"go.mod":  "module example.com/acme\n\ngo 1.23\n",
+			"acme.go": apiBaseline,
+		}, test.TagName)
+		b := &bump.Bump{
+			FS:        fs,
+			Git:       gi,
+			WaitGroup: new(sync.WaitGroup),
+		}
+		a.Nil(afero.WriteFile(b.FS, "acme.go", []byte(test.NewContent), 0644))
+
+		versionType, err := b.Suggest(&bump.RunArgs{})
+		if test.ExpectedErrorStr != "" {
+			a.EqualError(err, test.ExpectedErrorStr)
+		} else {
+			a.Nil(err)
+			a.Equal(test.ExpectedType, versionType)
+		}
+	}
+}
+
+func TestBump_VerifyAPI(t *testing.T) {
+	a := assert.New(t)
+
+	gi, fs := newTaggedRepository(t, map[string]string{
+		"go.mod":  "module example.com/acme\n\ngo 1.23\n",
+		"acme.go": apiBaseline,
+	}, "v1.2.0")
+	b := &bump.Bump{
+		FS:  fs,
+		Git: gi,
+		Configuration: bump.Configuration{
+			langs.Config{Name: golang.Name, Enabled: true, Directories: []string{"."}},
+		},
+		WaitGroup: new(sync.WaitGroup),
+	}
+	a.Nil(afero.WriteFile(b.FS, "acme.go", []byte("package acme\n\nconst Version = \"1.2.0\"\n"), 0644))
+
+	err := b.Bump(&bump.RunArgs{
+		VersionType: version.Minor,
+		VerifyAPI:   true,
+		IsDryRun:    true,
+	})
+	a.EqualError(err, fmt.Sprintf(bump.ErrStrFormattedInsufficientVersionType, "v1.2.0", "major", "minor"))
+
+	err = b.Bump(&bump.RunArgs{
+		VersionType: version.Major,
+		VerifyAPI:   true,
+		IsDryRun:    true,
+	})
+	a.Nil(err)
+}
+
+func TestBump_AutoVersionType(t *testing.T) {
+	type test struct {
+		TagName string
+		// Version is the version of the files when there is no tag
+		Version          string
+		Messages         []string
+		ExpectedVersion  string
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Fix": {
+			TagName:         "v1.2.0",
+			Messages:        []string{"docs: typo", "fix: crash on empty input"},
+			ExpectedVersion: "1.2.1",
+		},
+		"Feature": {
+			TagName:         "v1.2.0",
+			Messages:        []string{"fix: crash on empty input", "feat(api): add login", "chore: deps"},
+			ExpectedVersion: "1.3.0",
+		},
+		"Breaking Change Footer": {
+			TagName:         "v1.2.0",
+			Messages:        []string{"feat: add login", "refactor: rename client\n\nBREAKING CHANGE: Client is now Session"},
+			ExpectedVersion: "2.0.0",
+		},
+		"Breaking Change In Initial Development": {
+			TagName:         "v0.4.0",
+			Messages:        []string{"feat!: new api"},
+			ExpectedVersion: "0.5.0",
+		},
+		"Breaking Change In Initial Development Without A Tag": {
+			Version:         "0.4.0",
+			Messages:        []string{"fix: crash on empty input", "feat!: new api"},
+			ExpectedVersion: "0.5.0",
+		},
+		"Breaking Change Without A Tag": {
+			Version:         "1.4.0",
+			Messages:        []string{"feat!: new api"},
+			ExpectedVersion: "2.0.0",
+		},
+		"Nothing To Release": {
+			TagName:          "v1.2.0",
+			Messages:         []string{"docs: typo", "Merge branch 'main'"},
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedNoReleasableCommits, "v1.2.0"),
+		},
+		"Nothing To Release Without A Tag": {
+			Version:          "0.4.0",
+			Messages:         []string{"docs: typo"},
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedNoReleasableCommits, "the first commit"),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		current := test.Version
+		if test.TagName != "" {
+			current = strings.TrimPrefix(test.TagName, "v")
+		}
+		gi, fs := newTaggedRepository(t, map[string]string{"VERSION": current + "\n"}, test.TagName)
+		for i, message := range test.Messages {
+			commitFiles(t, gi, message, map[string]string{fmt.Sprintf("file-%d.txt", i): message})
+		}
+
+		b := &bump.Bump{
+			FS:  fs,
+			Git: gi,
+			Configuration: bump.Configuration{
+				langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"."}},
+			},
+			WaitGroup: new(sync.WaitGroup),
+		}
+
+		ra := &bump.RunArgs{VersionType: version.Auto}
+		err := b.Bump(ra)
+		if test.ExpectedErrorStr != "" {
+			a.EqualError(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+		a.Equal(version.Auto, ra.VersionType)
+
+		content, _ := afero.ReadFile(fs, "VERSION")
+		a.Equal(test.ExpectedVersion+"\n", string(content))
+		_, err = gi.Repository.(*gogit.Repository).Tag("v" + test.ExpectedVersion)
+		a.Nil(err)
+	}
+}
+
+func TestBump_Changelog(t *testing.T) {
+	type test struct {
+		Changelog        changelog.Config
+		Existing         string
+		IsDryRun         bool
+		ExpectedFile     string
+		ExpectedContent  string
+		ExpectedSuffix   string
+		ExpectedTagFirst string
+	}
+
+	date := time.Now().Format(changelog.DateFormat)
+
+	suite := map[string]test{
+		"New Changelog": {
+			Changelog:        changelog.Config{Enabled: true},
+			ExpectedFile:     "CHANGELOG.md",
+			ExpectedContent:  "# Changelog\n\n## [1.3.0] - " + date + "\n\n### Features\n\n- **api:** add login",
+			ExpectedTagFirst: "1.3.0",
+		},
+		"Existing Changelog As Tag Message": {
+			Changelog:        changelog.Config{Enabled: true, File: "docs/CHANGES.md", URL: "https://github.com/acme/app", TagMessage: true},
+			Existing:         "# Changelog\n\n## [1.2.0] - 2026-01-02\n",
+			ExpectedFile:     "docs/CHANGES.md",
+			ExpectedContent:  "# Changelog\n\n## [1.3.0](https://github.com/acme/app/compare/v1.2.0...v1.3.0) - " + date + "\n",
+			ExpectedSuffix:   "\n## [1.2.0] - 2026-01-02\n",
+			ExpectedTagFirst: "## [1.3.0](https://github.com/acme/app/compare/v1.2.0...v1.3.0) - " + date,
+		},
+		"Dry Run": {
+			Changelog:    changelog.Config{Enabled: true},
+			IsDryRun:     true,
+			ExpectedFile: "CHANGELOG.md",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		files := map[string]string{"VERSION": "1.2.0\n"}
+		if test.Existing != "" {
+			files[test.ExpectedFile] = test.Existing
+		}
+		gi, fs := newTaggedRepository(t, files, "v1.2.0")
+		commitFiles(t, gi, "feat(api): add login", map[string]string{"api.txt": "login"})
+		commitFiles(t, gi, "fix: crash on empty input\n\nCloses #7", map[string]string{"fix.txt": "fix"})
+
+		b := &bump.Bump{
+			FS:  fs,
+			Git: gi,
+			Configuration: bump.Configuration{
+				langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"."}},
+			},
+			Changelog: test.Changelog,
+			WaitGroup: new(sync.WaitGroup),
+		}
+
+		err := b.Bump(&bump.RunArgs{VersionType: version.Minor, IsDryRun: test.IsDryRun})
+		a.Nil(err)
+
+		content, _ := afero.ReadFile(fs, test.ExpectedFile)
+		if test.IsDryRun {
+			a.Empty(content)
+			continue
+		}
+		a.True(strings.HasPrefix(string(content), test.ExpectedContent), string(content))
+		a.Contains(string(content), "### Bug Fixes")
+		a.True(strings.HasSuffix(string(content), test.ExpectedSuffix))
+
+		repo := gi.Repository.(*gogit.Repository)
+		ref, err := repo.Tag("v1.3.0")
+		a.Nil(err)
+		tag, err := repo.TagObject(ref.Hash())
+		a.Nil(err)
+		a.True(strings.HasPrefix(tag.Message, test.ExpectedTagFirst), tag.Message)
+
+		commit, err := tag.Commit()
+		a.Nil(err)
+		file, err := commit.File(test.ExpectedFile)
+		a.Nil(err)
+		committed, err := file.Contents()
+		a.Nil(err)
+		a.Equal(string(content), committed)
+	}
+}
+
+func TestBump_ChangelogUnreleased(t *testing.T) {
+	type test struct {
+		Existing         string
+		AllowEmpty       bool
+		ExpectedContent  string
+		ExpectedErrorStr string
+	}
+
+	date := time.Now().Format(changelog.DateFormat)
+
+	suite := map[string]test{
+		"Promote": {
+			Existing:        "# Changelog\n\n## [Unreleased]\n\n- login\n\n[unreleased]: https://github.com/acme/app/compare/v1.2.0...HEAD\n",
+			ExpectedContent: "# Changelog\n\n## [Unreleased]\n\n## [1.3.0] - " + date + "\n\n- login\n\n[unreleased]: https://github.com/acme/app/compare/v1.3.0...HEAD\n[1.3.0]: https://github.com/acme/app/compare/v1.2.0...v1.3.0\n",
+		},
+		"Empty Section Allowed": {
+			Existing:        "## [Unreleased]\n",
+			AllowEmpty:      true,
+			ExpectedContent: "## [Unreleased]\n\n## [1.3.0] - " + date + "\n",
+		},
+		"Empty Section": {
+			Existing:         "## [Unreleased]\n\n## [1.2.0] - 2026-01-02\n",
+			ExpectedErrorStr: fmt.Sprintf("%s: %s", fmt.Sprintf(bump.ErrStrFormattedWritingChangelog, "CHANGELOG.md"), changelog.ErrStrEmptyUnreleasedSection),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		gi, fs := newTaggedRepository(t, map[string]string{"VERSION": "1.2.0\n", "CHANGELOG.md": test.Existing}, "v1.2.0")
+
+		b := &bump.Bump{
+			FS:  fs,
+			Git: gi,
+			Configuration: bump.Configuration{
+				langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"."}},
+			},
+			Changelog: changelog.Config{Enabled: true, Unreleased: true, TagMessage: true},
+			WaitGroup: new(sync.WaitGroup),
+		}
+
+		err := b.Bump(&bump.RunArgs{VersionType: version.Minor, AllowEmptyChangelog: test.AllowEmpty})
+		if test.ExpectedErrorStr != "" {
+			a.EqualError(err, test.ExpectedErrorStr)
+			// the version files are left untouched
+			content, _ := afero.ReadFile(fs, "VERSION")
+			a.Equal("1.2.0\n", string(content))
+			continue
+		}
+		a.Nil(err)
+
+		content, _ := afero.ReadFile(fs, "CHANGELOG.md")
+		a.Equal(test.ExpectedContent, string(content))
+
+		repo := gi.Repository.(*gogit.Repository)
+		ref, err := repo.Tag("v1.3.0")
+		a.Nil(err)
+		tag, err := repo.TagObject(ref.Hash())
+		a.Nil(err)
+		a.True(strings.HasPrefix(tag.Message, "## [1.3.0] - "+date), tag.Message)
+	}
+}
+
+func TestBump_GitTemplates(t *testing.T) {
+	a := assert.New(t)
+
+	gi, fs := newTaggedRepository(t, map[string]string{"VERSION": "1.2.0\n"}, "1.2.0")
+	gi.TagTemplate = "{{.Version}}"
+	gi.CommitMessageTemplate = "release: v{{.Version}} [skip ci]\n\nSigned-off-by: {{.Author}}"
+	gi.TagMessageTemplate = "Release {{.OldVersion}} -> {{.Version}} ({{range .Files}}{{.}}{{end}})"
+
+	b := &bump.Bump{
+		FS:  fs,
+		Git: gi,
+		Configuration: bump.Configuration{
+			langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"."}},
+		},
+		WaitGroup: new(sync.WaitGroup),
+	}
+
+	a.Nil(b.Bump(&bump.RunArgs{VersionType: version.Patch}))
+
+	repo := gi.Repository.(*gogit.Repository)
+	ref, err := repo.Tag("1.2.1")
+	a.Nil(err)
+	tag, err := repo.TagObject(ref.Hash())
+	a.Nil(err)
+	a.Equal("Release 1.2.0 -> 1.2.1 (VERSION)\n", tag.Message)
+
+	commit, err := tag.Commit()
+	a.Nil(err)
+	a.Equal(fmt.Sprintf("release: v1.2.1 [skip ci]\n\nSigned-off-by: %s <%s>", git.Username, git.Email), commit.Message)
+}
+
+func TestBump_Push(t *testing.T) {
+	a := assert.New(t)
+
+	gi, fs := newTaggedRepository(t, map[string]string{"VERSION": "1.2.0\n"}, "v1.2.0")
+	bareDir := t.TempDir()
+	bare, err := gogit.PlainInit(bareDir, true)
+	a.Nil(err)
+	_, err = gi.Repository.(*gogit.Repository).CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{bareDir}})
+	a.Nil(err)
+
+	b := &bump.Bump{
+		FS:  fs,
+		Git: gi,
+		Configuration: bump.Configuration{
+			langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"."}},
+		},
+		Push:      git.PushConfig{Remote: "upstream"},
+		WaitGroup: new(sync.WaitGroup),
+	}
+
+	a.Nil(b.Bump(&bump.RunArgs{VersionType: version.Minor, Push: true}))
+
+	head, err := gi.Repository.Head()
+	a.Nil(err)
+	remoteHead, err := bare.Reference(head.Name(), true)
+	a.Nil(err)
+	a.Equal(head.Hash(), remoteHead.Hash())
+	_, err = bare.Tag("v1.3.0")
+	a.Nil(err)
+}
+
+func TestBump_PreflightChecks(t *testing.T) {
+	type test struct {
+		Checks           git.ChecksConfig
+		RunArgs          bump.RunArgs
+		TagName          string
+		UntrackedFile    string
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Clean": {
+			Checks: git.ChecksConfig{Clean: true, Branches: []string{"main", "master"}, Upstream: true, Untagged: true},
+		},
+		"Dirty": {
+			Checks:           git.ChecksConfig{Clean: true},
+			UntrackedFile:    "notes.txt",
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedDirtyWorktree, "notes.txt"),
+		},
+		"Dirty Allowed": {
+			Checks:        git.ChecksConfig{Clean: true},
+			RunArgs:       bump.RunArgs{AllowDirty: true},
+			UntrackedFile: "notes.txt",
+		},
+		"Branch Not Allowed": {
+			Checks:           git.ChecksConfig{Branches: []string{"main", "release/*"}},
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedBranchNotAllowed, "master", "main, release/*"),
+		},
+		"Branch Allowed": {
+			Checks:  git.ChecksConfig{Branches: []string{"main", "release/*"}},
+			RunArgs: bump.RunArgs{AllowAnyBranch: true},
+		},
+		"Already Tagged": {
+			Checks:           git.ChecksConfig{Untagged: true},
+			TagName:          "v1.2.0",
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedHeadAlreadyTagged, "v1.2.0"),
+		},
+		"Already Tagged Allowed": {
+			Checks:  git.ChecksConfig{Untagged: true},
+			RunArgs: bump.RunArgs{AllowTagged: true},
+			TagName: "v1.2.0",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		gi, fs := newTaggedRepository(t, map[string]string{"VERSION": "1.2.0\n"}, test.TagName)
+		if test.UntrackedFile != "" {
+			a.Nil(afero.WriteFile(fs, test.UntrackedFile, []byte("todo"), 0644))
+		}
+
+		b := &bump.Bump{
+			FS:  fs,
+			Git: gi,
+			Configuration: bump.Configuration{
+				langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"."}},
+			},
+			Checks:    test.Checks,
+			WaitGroup: new(sync.WaitGroup),
+		}
+
+		ra := test.RunArgs
+		ra.VersionType = version.Patch
+		err := b.Bump(&ra)
+
+		content, _ := afero.ReadFile(fs, "VERSION")
+		if test.ExpectedErrorStr != "" {
+			a.EqualError(err, test.ExpectedErrorStr)
+			a.Equal("1.2.0\n", string(content))
+			continue
+		}
+		a.Nil(err)
+		a.Equal("1.2.1\n", string(content))
+	}
+}
+
+func TestBump_ReleaseTags(t *testing.T) {
+	type test struct {
+		Version string
+		// Directories each hold a VERSION file, the root of the repository by default
+		Directories      []string
+		Tags             []string
+		VersionType      version.Type
+		PrereleaseType   version.PrereleaseType
+		ExpectedVersion  string
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Next Patch": {
+			Version:         "1.2.0",
+			Tags:            []string{"v1.2.0"},
+			VersionType:     version.Patch,
+			ExpectedVersion: "1.2.1",
+		},
+		"Already Tagged": {
+			Version:          "1.2.0",
+			Tags:             []string{"v1.2.0", "v1.2.1"},
+			VersionType:      version.Patch,
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedVersionAlreadyTagged, "1.2.1", "v1.2.1"),
+		},
+		"Already Tagged In Every File": {
+			Version:          "1.2.0",
+			Directories:      []string{".", "api", "web"},
+			Tags:             []string{"v1.2.0", "v1.2.1"},
+			VersionType:      version.Patch,
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedVersionAlreadyTagged, "1.2.1", "v1.2.1"),
+		},
+		"Next Patch In Every File": {
+			Version:         "1.2.0",
+			Directories:     []string{".", "api", "web"},
+			Tags:            []string{"v1.2.0"},
+			VersionType:     version.Patch,
+			ExpectedVersion: "1.2.1",
+		},
+		"Below The Latest Minor": {
+			Version:          "1.2.0",
+			Tags:             []string{"v1.2.0", "v1.4.0"},
+			VersionType:      version.Minor,
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedVersionBelowRelease, "1.3.0", "v1.4.0"),
+		},
+		"Maintenance Patch": {
+			Version:         "1.2.0",
+			Tags:            []string{"v1.2.0", "v1.3.0", "v2.0.0"},
+			VersionType:     version.Patch,
+			ExpectedVersion: "1.2.1",
+		},
+		"Prerelease Counter": {
+			Version:         "1.2.0",
+			Tags:            []string{"v1.2.0", "v1.3.0-rc.0", "v1.3.0-rc.1", "v1.3.0-beta.4"},
+			VersionType:     version.Minor,
+			PrereleaseType:  version.ReleaseCandidate,
+			ExpectedVersion: "1.3.0-rc.2",
+		},
+		"Prerelease Of A Release": {
+			Version:          "1.3.0-rc.0",
+			Tags:             []string{"v1.3.0"},
+			PrereleaseType:   version.ReleaseCandidate,
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedVersionBelowRelease, "1.3.0-rc.1", "v1.3.0"),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		directories := test.Directories
+		if len(directories) == 0 {
+			directories = []string{"."}
+		}
+		files := make(map[string]string)
+		for _, dir := range directories {
+			files[path.Join(dir, "VERSION")] = test.Version + "\n"
+		}
+		gi, fs := newTaggedRepository(t, files, "")
+		head, err := gi.Repository.Head()
+		a.Nil(err)
+		for _, tag := range test.Tags {
+			_, err = gi.Repository.CreateTag(tag, head.Hash(), nil)
+			a.Nil(err)
+		}
+
+		b := &bump.Bump{
+			FS:  fs,
+			Git: gi,
+			Configuration: bump.Configuration{
+				langs.Config{Name: plaintext.Name, Enabled: true, Directories: directories},
+			},
+			WaitGroup: new(sync.WaitGroup),
+		}
+
+		err = b.Bump(&bump.RunArgs{VersionType: test.VersionType, PrereleaseType: test.PrereleaseType})
+
+		expected := test.ExpectedVersion
+		if test.ExpectedErrorStr != "" {
+			a.EqualError(err, test.ExpectedErrorStr)
+			expected = test.Version
+		} else {
+			a.Nil(err)
+		}
+		for name := range files {
+			content, _ := afero.ReadFile(fs, name)
+			a.Equal(expected+"\n", string(content), name)
+		}
+	}
+}
+
+func TestBump_TagOnly(t *testing.T) {
+	type test struct {
+		TagName          string
+		ConfigTagOnly    bool
+		RunArgs          bump.RunArgs
+		ExpectedTag      string
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Next Minor": {
+			TagName:     "v1.2.0",
+			RunArgs:     bump.RunArgs{VersionType: version.Minor, TagOnly: true},
+			ExpectedTag: "v1.3.0",
+		},
+		"Configured": {
+			TagName:       "v1.2.0",
+			ConfigTagOnly: true,
+			RunArgs:       bump.RunArgs{VersionType: version.Patch},
+			ExpectedTag:   "v1.2.1",
+		},
+		"Release Candidate": {
+			TagName:     "v1.2.0",
+			RunArgs:     bump.RunArgs{VersionType: version.Major, PrereleaseType: version.ReleaseCandidate, TagOnly: true},
+			ExpectedTag: "v2.0.0-rc.0",
+		},
+		"Without Tags": {
+			RunArgs:     bump.RunArgs{VersionType: version.Minor, TagOnly: true},
+			ExpectedTag: "v0.1.0",
+		},
+		"Dry Run": {
+			TagName: "v1.2.0",
+			RunArgs: bump.RunArgs{VersionType: version.Minor, TagOnly: true, IsDryRun: true},
+		},
+		"Prerelease Of A Release": {
+			TagName:          "v1.2.0",
+			RunArgs:          bump.RunArgs{PrereleaseType: version.ReleaseCandidate, TagOnly: true},
+			ExpectedErrorStr: fmt.Sprintf("%s: %s", fmt.Sprintf(bump.ErrStrFormattedBumpingVersion, "1.2.0"), version.ErrStrPreReleasingNonPrerelease),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		gi, fs := newTaggedRepository(t, map[string]string{"main.tf": "terraform {}\n"}, test.TagName)
+		head, err := gi.Repository.Head()
+		a.Nil(err)
+
+		b := &bump.Bump{
+			FS:  fs,
+			Git: gi,
+			Configuration: bump.Configuration{
+				langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"."}},
+			},
+			TagOnly:   test.ConfigTagOnly,
+			WaitGroup: new(sync.WaitGroup),
+		}
+
+		ra := test.RunArgs
+		err = b.Bump(&ra)
+
+		tags, tagsErr := gi.ReleaseTags()
+		a.Nil(tagsErr)
+		if test.ExpectedErrorStr != "" {
+			a.EqualError(err, test.ExpectedErrorStr)
+			a.Len(tags, 1)
+			continue
+		}
+		a.Nil(err)
+
+		after, err := gi.Repository.Head()
+		a.Nil(err)
+		a.Equal(head.Hash(), after.Hash())
+
+		if test.ExpectedTag == "" {
+			a.Len(tags, len(strings.Fields(test.TagName)))
+			continue
+		}
+		a.Equal(test.ExpectedTag, tags[0].Name)
+		a.Equal(head.Hash(), tags[0].Commit.Hash)
+	}
+}
+
+func TestBump_Describe(t *testing.T) {
+	type test struct {
+		TagName          string
+		Commits          int
+		ModifiedFile     bool
+		UntrackedFile    bool
+		ConfigFormat     string
+		RunArgs          bump.RunArgs
+		ExpectedFormat   string
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Next Patch": {
+			TagName:        "v1.3.2",
+			Commits:        2,
+			ExpectedFormat: "1.3.3-dev.2+g%s",
+		},
+		"Next Minor": {
+			TagName:        "v1.3.2",
+			Commits:        7,
+			RunArgs:        bump.RunArgs{VersionType: version.Minor},
+			ExpectedFormat: "1.4.0-dev.7+g%s",
+		},
+		"Dirty": {
+			TagName:        "v1.3.2",
+			Commits:        1,
+			ModifiedFile:   true,
+			ExpectedFormat: "1.3.3-dev.1+g%s.dirty",
+		},
+		"Untracked Files Are Not Dirty": {
+			TagName:        "v1.3.2",
+			Commits:        1,
+			UntrackedFile:  true,
+			ExpectedFormat: "1.3.3-dev.1+g%s",
+		},
+		"Released": {
+			TagName:        "v1.3.2",
+			ExpectedFormat: "1.3.2",
+		},
+		"Released And Dirty": {
+			TagName:        "v1.3.2",
+			ModifiedFile:   true,
+			ExpectedFormat: "1.3.3-dev.0+g%s.dirty",
+		},
+		"Without Tags": {
+			Commits:        1,
+			ExpectedFormat: "0.0.1-dev.2+g%s",
+		},
+		"Configured Format": {
+			TagName:        "v1.3.2",
+			Commits:        1,
+			ConfigFormat:   "{{.Next}}-snapshot.{{.Distance}}",
+			ExpectedFormat: "1.3.3-snapshot.1",
+		},
+		"Format Flag": {
+			TagName:        "v1.3.2",
+			Commits:        1,
+			ConfigFormat:   "{{.Next}}-snapshot.{{.Distance}}",
+			RunArgs:        bump.RunArgs{DescribeFormat: "{{.Version}}+{{.Distance}}.{{.Hash}}"},
+			ExpectedFormat: "1.3.2+1.%s",
+		},
+		"Invalid Format": {
+			TagName:          "v1.3.2",
+			Commits:          1,
+			RunArgs:          bump.RunArgs{DescribeFormat: "{{.Build}}"},
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedRenderingDescribeFormat, "{{.Build}}"),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		gi, fs := newTaggedRepository(t, map[string]string{"main.go": "package main\n"}, test.TagName)
+		for j := 0; j < test.Commits; j++ {
+			commitFiles(t, gi, fmt.Sprintf("fix: change %d", j), map[string]string{"main.go": fmt.Sprintf("package main\n// %d\n", j)})
+		}
+		if test.ModifiedFile {
+			a.Nil(afero.WriteFile(fs, "main.go", []byte("package app\n"), 0644))
+		}
+		if test.UntrackedFile {
+			a.Nil(afero.WriteFile(fs, "notes.txt", []byte("todo"), 0644))
+		}
+		head, err := gi.Repository.Head()
+		a.Nil(err)
+
+		b := &bump.Bump{
+			FS:             fs,
+			Git:            gi,
+			DescribeConfig: git.DescribeConfig{Format: test.ConfigFormat},
+			WaitGroup:      new(sync.WaitGroup),
+		}
+
+		ra := test.RunArgs
+		described, err := b.Describe(&ra)
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+		expected := test.ExpectedFormat
+		if strings.Contains(expected, "%s") {
+			expected = fmt.Sprintf(expected, head.Hash().String()[:git.ShortHashLength])
+		}
+		a.Equal(expected, described)
+	}
+}
+
+func TestBump_WithVanillaFsRepoDoesntExist(t *testing.T) {
+	a := assert.New(t)
+	_, err := bump.New(t.TempDir())
+	a.ErrorContains(err, fmt.Sprintf("%s: repository does not exist", git.ErrStrOpeningRepo))
+}
+
+func TestBump_WithVanillaFsDiscovery(t *testing.T) {
+	type test struct {
+		Dir                   string
+		ExpectedDir           string
+		ExpectedConfiguration bump.Configuration
+		ExpectedPackages      []bump.Package
+	}
+
+	suite := map[string]test{
+		"Root": {
+			Dir:         ".",
+			ExpectedDir: ".",
+			ExpectedConfiguration: bump.Configuration{
+				langs.Config{Name: golang.Name, Enabled: true, Directories: []string{"cmd"}},
+			},
+		},
+		"Subdirectory Without Configuration": {
+			Dir:         "cmd",
+			ExpectedDir: ".",
+			ExpectedConfiguration: bump.Configuration{
+				langs.Config{Name: golang.Name, Enabled: true, Directories: []string{"cmd"}},
+			},
+		},
+		"Subdirectory With Configuration": {
+			Dir:         "services/web",
+			ExpectedDir: "services/web",
+			ExpectedConfiguration: bump.Configuration{
+				langs.Config{Name: js.Name, Enabled: true, Directories: []string{"services/web/app"}},
+			},
+			ExpectedPackages: []bump.Package{
+				{
+					Name:        "admin",
+					TagTemplate: "admin-v{{version}}",
+					Directories: []string{"services/web/admin"},
+					Configuration: bump.Configuration{
+						langs.Config{Name: plaintext.Name, Enabled: true, Directories: []string{"services/web/admin"}},
+					},
+				},
+			},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		root := t.TempDir()
+		_, err := gogit.PlainInit(root, false)
+		a.Nil(err)
+		osFs := afero.NewBasePathFs(afero.NewOsFs(), root)
+		a.Nil(osFs.MkdirAll("cmd", 0755))
+		a.Nil(osFs.MkdirAll("services/web", 0755))
+		a.Nil(afero.WriteFile(osFs, ".bump", []byte("[go]\nenabled = true\ndirectories = [ 'cmd' ]\n"), 0644))
+		a.Nil(afero.WriteFile(osFs, "services/web/.bump", []byte(`[javascript]
+enabled = true
+directories = [ 'app' ]
+
+[[package]]
+name = "admin"
+directories = [ 'admin' ]
+
+[package.plaintext]
+enabled = true
+`), 0644))
+
+		b, err := bump.New(path.Join(root, test.Dir))
+		a.Nil(err)
+		a.Equal(test.ExpectedDir, b.Dir)
+		a.Equal(test.ExpectedConfiguration, b.Configuration)
+		a.Equal(test.ExpectedPackages, b.Packages)
+
+		exists, err := afero.Exists(b.FS, "services/web/.bump")
+		a.Nil(err)
+		a.True(exists)
+	}
+}
+
+func TestBump_HookRollback(t *testing.T) {
+	type test struct {
+		Hooks            map[string]string
+		ExpectedVersion  string
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Pre-Commit Fails": {
+			Hooks:            map[string]string{git.PreCommitHook: "exit 1"},
+			ExpectedVersion:  "1.0.0",
+			ExpectedErrorStr: "running the pre-commit hook: exit status 1",
+		},
+		"Commit-Msg Fails": {
+			Hooks:            map[string]string{git.CommitMsgHook: "exit 1"},
+			ExpectedVersion:  "1.0.0",
+			ExpectedErrorStr: "running the commit-msg hook: exit status 1",
+		},
+		"Hooks Pass": {
+			Hooks:           map[string]string{git.PreCommitHook: "exit 0"},
+			ExpectedVersion: "1.1.0",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+		t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+
+		root := t.TempDir()
+		repo, err := gogit.PlainInit(root, false)
+		a.Nil(err)
+		osFs := afero.NewBasePathFs(afero.NewOsFs(), root)
+		a.Nil(afero.WriteFile(osFs, "VERSION", []byte("1.0.0\n"), 0644))
+		a.Nil(afero.WriteFile(osFs, ".bump", []byte("[plaintext]\nenabled = true\n\n[changelog]\nenabled = true\n\n[git]\nrun_hooks = true\n"), 0644))
+		wt, err := repo.Worktree()
+		a.Nil(err)
+		_, err = wt.Add(".")
+		a.Nil(err)
+		initial, err := wt.Commit("feat: initial", &gogit.CommitOptions{
+			Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+		})
+		a.Nil(err)
+		a.Nil(osFs.MkdirAll(".git/hooks", 0755))
+		for hook, script := range test.Hooks {
+			a.Nil(afero.WriteFile(osFs, path.Join(".git/hooks", hook), []byte("#!/bin/sh\n"+script+"\n"), 0755))
+		}
+
+		b, err := bump.New(root)
+		a.Nil(err)
+		err = b.Bump(&bump.RunArgs{
+			VersionType:        version.Minor,
+			ConfirmationPrompt: func(string, string, string) (bool, error) { return true, nil },
+		})
+
+		content, readErr := afero.ReadFile(osFs, "VERSION")
+		a.Nil(readErr)
+		a.Equal(test.ExpectedVersion+"\n", string(content))
+		head, headErr := repo.Head()
+		a.Nil(headErr)
+
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			a.Equal(initial, head.Hash())
+			exists, existsErr := afero.Exists(osFs, changelog.DefaultFile)
+			a.Nil(existsErr)
+			a.False(exists)
+			status, statusErr := wt.Status()
+			a.Nil(statusErr)
+			a.True(status.IsClean())
+			continue
+		}
+		a.Nil(err)
+		a.NotEqual(initial, head.Hash())
+	}
+}
+
+func TestBump_Undo(t *testing.T) {
+	type test struct {
+		IsDryRun        bool
+		Sign            bool
+		ExpectedVersion string
+		ExpectedTagged  bool
+	}
+
+	suite := map[string]test{
+		"Undo": {
+			ExpectedVersion: "1.1.0",
+		},
+		"Dry Run": {
+			IsDryRun:        true,
+			ExpectedVersion: "1.2.0",
+			ExpectedTagged:  true,
+		},
+		"Signed Alias Tags": {
+			Sign:            true,
+			ExpectedVersion: "1.1.0",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+		t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+
+		root := t.TempDir()
+		repo, err := gogit.PlainInit(root, false)
+		a.Nil(err)
+		osFs := afero.NewBasePathFs(afero.NewOsFs(), root)
+		a.Nil(afero.WriteFile(osFs, "VERSION", []byte("1.0.0\n"), 0644))
+		a.Nil(afero.WriteFile(osFs, ".bump", []byte("[plaintext]\nenabled = true\n\n[git]\ncommit_message = \"release: v{{.Version}}\"\nalias_tags = [ \"v{{major}}\" ]\n"), 0644))
+		wt, err := repo.Worktree()
+		a.Nil(err)
+		_, err = wt.Add(".")
+		a.Nil(err)
+		_, err = wt.Commit("initial", &gogit.CommitOptions{
+			Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+		})
+		a.Nil(err)
+
+		// the signing key of the repository configuration signs the releases and the alias tags restored by undo
+		var passphrasePrompt func() (string, error)
+		if test.Sign {
+			cfg, err := repo.Config()
+			a.Nil(err)
+			cfg.Raw.Section("commit").SetOption("gpgsign", "true")
+			cfg.Raw.Section("user").SetOption("signingkey", "signing-key")
+			a.Nil(repo.SetConfig(cfg))
+
+			entity, err := openpgp.NewEntity(git.Username, "", git.Email, nil)
+			a.Nil(err)
+			gea := new(mocks.GpgEntityAccessor)
+			gea.On("GetEntity", "", "signing-key").Return(entity, nil)
+			gpgEntityAccessor, gitConfigParser := bump.GpgEntityAccessor, bump.GitConfigParser
+			defer func() { bump.GpgEntityAccessor, bump.GitConfigParser = gpgEntityAccessor, gitConfigParser }()
+			bump.GpgEntityAccessor = gea
+			bump.GitConfigParser = new(git.ConfigParser)
+			passphrasePrompt = func() (string, error) { return "", nil }
+		}
+
+		b, err := bump.New(root)
+		a.Nil(err)
+		released := make([]plumbing.Hash, 0)
+		for range 2 {
+			a.Nil(b.Bump(&bump.RunArgs{
+				VersionType:        version.Minor,
+				ConfirmationPrompt: func(string, string, string) (bool, error) { return true, nil },
+				PassphrasePrompt:   passphrasePrompt,
+			}))
+			head, err := repo.Head()
+			a.Nil(err)
+			released = append(released, head.Hash())
+		}
+
+		a.Nil(b.Undo(&bump.RunArgs{IsDryRun: test.IsDryRun, PassphrasePrompt: passphrasePrompt}))
+
+		content, err := afero.ReadFile(osFs, "VERSION")
+		a.Nil(err)
+		a.Equal(test.ExpectedVersion+"\n", string(content))
+		_, err = repo.Tag("v1.2.0")
+		a.Equal(test.ExpectedTagged, err == nil)
+		head, err := repo.Head()
+		a.Nil(err)
+		a.Equal(!test.ExpectedTagged, head.Hash() == released[0])
+
+		// the alias tag moves back onto the previous release
+		expectedAlias := released[0]
+		if test.ExpectedTagged {
+			expectedAlias = released[1]
+		}
+		alias, err := repo.Tag("v1")
+		a.Nil(err)
+		aliasTag, err := repo.TagObject(alias.Hash())
+		a.Nil(err)
+		a.Equal(expectedAlias, aliasTag.Target)
+		a.Equal(test.Sign, aliasTag.PGPSignature != "")
+	}
+}
+
+func TestBump_Branches(t *testing.T) {
+	type test struct {
+		Branch                 string
+		Branches               string
+		VersionType            version.Type
+		PrereleaseType         version.PrereleaseType
+		ExpectedVersion        string
+		ExpectedBranches       []string
+		ExpectedConfigErrorStr string
+		ExpectedErrorStr       string
+		// Tags are created on the initial commit
+		Tags []string
+	}
+
+	const policy = `[branches]
+main = [ 'main' ]
+create_release_branch = true
+maintenance = [ 'release/*' ]
+
+[branches.prereleases]
+develop = "beta"
+"feature/*" = "alpha"
+`
+
+	suite := map[string]test{
+		"Minor Creates A Release Branch": {
+			Branch:           "main",
+			Branches:         policy,
+			VersionType:      version.Minor,
+			ExpectedVersion:  "1.3.0",
+			ExpectedBranches: []string{"main", "release/1.3"},
+		},
+		"Release Branch Template": {
+			Branch:           "main",
+			Branches:         "[branches]\nmain = [ 'main' ]\ncreate_release_branch = true\nrelease_branch = 'maint/v{{major}}'\n",
+			VersionType:      version.Major,
+			ExpectedVersion:  "2.0.0",
+			ExpectedBranches: []string{"main", "maint/v2"},
+		},
+		"Patch Creates No Release Branch": {
+			Branch:           "main",
+			Branches:         policy,
+			VersionType:      version.Patch,
+			ExpectedVersion:  "1.2.4",
+			ExpectedBranches: []string{"main"},
+		},
+		"Prerelease Creates No Release Branch": {
+			Branch:           "main",
+			Branches:         policy,
+			VersionType:      version.Minor,
+			PrereleaseType:   version.ReleaseCandidate,
+			ExpectedVersion:  "1.3.0-rc.0",
+			ExpectedBranches: []string{"main"},
+		},
+		"Release Branch Not Requested": {
+			Branch:           "main",
+			Branches:         "[branches]\nmain = [ 'main' ]\n",
+			VersionType:      version.Minor,
+			ExpectedVersion:  "1.3.0",
+			ExpectedBranches: []string{"main"},
+		},
+		"Maintenance Patch": {
+			Branch:           "release/1.2",
+			Branches:         policy,
+			VersionType:      version.Patch,
+			ExpectedVersion:  "1.2.4",
+			ExpectedBranches: []string{"release/1.2"},
+		},
+		"Maintenance Prerelease": {
+			Branch:           "release/1.2",
+			Branches:         policy,
+			VersionType:      version.Patch,
+			PrereleaseType:   version.ReleaseCandidate,
+			ExpectedVersion:  "1.2.4-rc.0",
+			ExpectedBranches: []string{"release/1.2"},
+		},
+		"Maintenance Minor": {
+			Branch:           "release/1.2",
+			Branches:         policy,
+			VersionType:      version.Minor,
+			ExpectedVersion:  "1.2.3",
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedMaintenanceBranch, "release/1.2", "minor"),
+		},
+		"Maintenance Major": {
+			Branch:           "release/1.2",
+			Branches:         policy,
+			VersionType:      version.Major,
+			ExpectedVersion:  "1.2.3",
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedMaintenanceBranch, "release/1.2", "major"),
+		},
+		"Prerelease Channel": {
+			Branch:           "develop",
+			Branches:         policy,
+			VersionType:      version.Minor,
+			ExpectedVersion:  "1.3.0-beta.0",
+			ExpectedBranches: []string{"develop"},
+		},
+		"Prerelease Channel Pattern": {
+			Branch:           "feature/login",
+			Branches:         policy,
+			VersionType:      version.Minor,
+			PrereleaseType:   version.AlphaPrerelease,
+			ExpectedVersion:  "1.3.0-alpha.0",
+			ExpectedBranches: []string{"feature/login"},
+		},
+		"Prerelease Channel Mismatch": {
+			Branch:           "develop",
+			Branches:         policy,
+			VersionType:      version.Minor,
+			PrereleaseType:   version.ReleaseCandidate,
+			ExpectedVersion:  "1.2.3",
+			ExpectedErrorStr: fmt.Sprintf(bump.ErrStrFormattedPrereleaseChannel, "develop", "beta", "rc"),
+		},
+		"Invalid Prerelease Channel": {
+			Branch:                 "main",
+			Branches:               "[branches.prereleases]\ndevelop = \"nightly\"\n",
+			ExpectedConfigErrorStr: fmt.Sprintf(bump.ErrStrFormattedInvalidPrereleaseChannel, "develop", "nightly", "alpha, beta, rc"),
+		},
+		"Branch Prerelease": {
+			Branch:           "feat/login",
+			VersionType:      version.Minor,
+			PrereleaseType:   version.BranchPrerelease,
+			ExpectedVersion:  "1.3.0-feat-login.0",
+			ExpectedBranches: []string{"feat/login"},
+		},
+		"Branch Prerelease Channel": {
+			Branch:           "fix/Crash_On_Start",
+			Branches:         "[branches.prereleases]\n\"fix/*\" = \"branch\"\n",
+			VersionType:      version.Patch,
+			ExpectedVersion:  "1.2.4-fix-crash-on-start.0",
+			ExpectedBranches: []string{"fix/Crash_On_Start"},
+		},
+		"Branch Prerelease Counts Tags": {
+			Branch:           "feat/login",
+			VersionType:      version.Minor,
+			PrereleaseType:   version.BranchPrerelease,
+			Tags:             []string{"v1.3.0-feat-login.0", "v1.3.0-feat-login.1"},
+			ExpectedVersion:  "1.3.0-feat-login.2",
+			ExpectedBranches: []string{"feat/login"},
+		},
+		"Branch Prerelease Counts Commits": {
+			Branch:           "feat/login",
+			Branches:         "[branches]\nprerelease_counter = 'commits'\n",
+			VersionType:      version.Minor,
+			PrereleaseType:   version.BranchPrerelease,
+			ExpectedVersion:  "1.3.0-feat-login.1",
+			ExpectedBranches: []string{"feat/login"},
+		},
+		"Branch Prerelease Template": {
+			Branch:           "feat/login",
+			Branches:         "[branches]\nprerelease_identifier = 'pr.{{.Branch}}'\n",
+			VersionType:      version.Minor,
+			PrereleaseType:   version.BranchPrerelease,
+			ExpectedVersion:  "1.3.0-pr.feat-login.0",
+			ExpectedBranches: []string{"feat/login"},
+		},
+		"Numeric Branch Prerelease": {
+			Branch:           "1234",
+			VersionType:      version.Minor,
+			PrereleaseType:   version.BranchPrerelease,
+			ExpectedVersion:  "1.2.3",
+			ExpectedErrorStr: fmt.Sprintf(version.ErrStrFormattedInvalidPrereleaseIdentifier, "1234"),
+		},
+		"Invalid Prerelease Counter": {
+			Branch:                 "main",
+			Branches:               "[branches]\nprerelease_counter = 'builds'\n",
+			ExpectedConfigErrorStr: fmt.Sprintf(bump.ErrStrFormattedInvalidPrereleaseCounter, "builds", "tags, commits"),
+		},
+		"Invalid Pattern": {
+			Branch:                 "main",
+			Branches:               "[branches]\nmaintenance = [ 'release/[' ]\n",
+			ExpectedConfigErrorStr: fmt.Sprintf(bump.ErrStrFormattedInvalidBranch, "release/["),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+		t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+
+		root := t.TempDir()
+		repo, err := gogit.PlainInitWithOptions(root, &gogit.PlainInitOptions{
+			InitOptions: gogit.InitOptions{DefaultBranch: plumbing.NewBranchReferenceName(test.Branch)},
+		})
+		a.Nil(err)
+		osFs := afero.NewBasePathFs(afero.NewOsFs(), root)
+		a.Nil(afero.WriteFile(osFs, "VERSION", []byte("1.2.3\n"), 0644))
+		a.Nil(afero.WriteFile(osFs, ".bump", []byte("[plaintext]\nenabled = true\n\n"+test.Branches), 0644))
+		wt, err := repo.Worktree()
+		a.Nil(err)
+		_, err = wt.Add(".")
+		a.Nil(err)
+		initial, err := wt.Commit("initial", &gogit.CommitOptions{
+			Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+		})
+		a.Nil(err)
+		for _, tag := range test.Tags {
+			_, err = repo.CreateTag(tag, initial, nil)
+			a.Nil(err)
+		}
+
+		b, err := bump.New(root)
+		if test.ExpectedConfigErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedConfigErrorStr)
+			continue
+		}
+		if !a.Nil(err) {
+			continue
+		}
+
+		err = b.Bump(&bump.RunArgs{
+			VersionType:        test.VersionType,
+			PrereleaseType:     test.PrereleaseType,
+			ConfirmationPrompt: func(string, string, string) (bool, error) { return true, nil },
+		})
+
+		content, readErr := afero.ReadFile(osFs, "VERSION")
+		a.Nil(readErr)
+		a.Equal(test.ExpectedVersion+"\n", string(content))
+
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+
+		head, err := repo.Head()
+		a.Nil(err)
+		branches := make([]string, 0)
+		refs, err := repo.Branches()
+		a.Nil(err)
+		a.Nil(refs.ForEach(func(ref *plumbing.Reference) error {
+			branches = append(branches, ref.Name().Short())
+			a.Equal(head.Hash(), ref.Hash())
+			return nil
+		}))
+		a.Equal(test.ExpectedBranches, branches)
+	}
+}
+
+func TestBump_BrokenBumpFile(t *testing.T) {
+	a := assert.New(t)
+	fs := afero.NewMemMapFs()
+	meta := memfs.New()
+	data := memfs.New()
+	_ = git.Init(meta, data)
+	f, err := fs.Create(".bump")
+	_, err = f.WriteString("brokenbump-contents")
+	_, err = bump.From(fs, meta, data, ".")
+	a.ErrorContains(err, bump.ErrStrParsingConfigFile)
+}
+
+func TestBump_ConfirmationError(t *testing.T) {
+	a := assert.New(t)
+
+	testSuite := testSuites["Go - Single Constant #2"]
+
+	_, err := runBumpTest(t, testSuite, &bump.RunArgs{
+		VersionType:    testSuite.VersionType,
+		PrereleaseType: testSuite.PrereleaseType,
+		ConfirmationPrompt: func(_ string, _ string, _ string) (bool, error) {
+			return true, fmt.Errorf("confirmation_error")
+		},
+	})
+	a.ErrorContains(err, fmt.Sprintf("%s: confirmation_error", bump.ErrStrDuringConfirmationPrompt))
+}
+
+func TestBump_ConfirmationDenied(t *testing.T) {
+	a := assert.New(t)
+
+	testSuite := testSuites["Go - Single Constant #2"]
+
+	_, err := runBumpTest(t, testSuite, &bump.RunArgs{
+		VersionType:    testSuite.VersionType,
+		PrereleaseType: testSuite.PrereleaseType,
+		ConfirmationPrompt: func(_ string, _ string, _ string) (bool, error) {
+			return false, nil
+		},
+	})
+	//currently we continue through the loop instead of returning an error
+	a.Nil(err)
+}
 
 func GitConfigParserMockScenarioOne() *mocks.GitConfigParser {
 	cpm := new(mocks.GitConfigParser)
@@ -1436,52 +3900,28 @@ func TestBump_PassphraseGetShouldNotSign(t *testing.T) {
 	a.Empty(err)
 }
 
-func TestBump_PassphraseGetShouldNotSignLoadConfigFails(t *testing.T) {
-	a := assert.New(t)
-
-	testSuite := testSuites["Go - Single Constant #2"]
-
-	gcp := new(mocks.GitConfigParser)
-	defer gcp.AssertExpectations(t)
-	gcp.On("SetConfig", mock.AnythingOfType("*config.Config")).Return(nil)
-	gcp.On("GetSectionOption", "commit", "gpgsign").Return("true")
-	gcp.On("GetSectionOption", "user", "signingkey").Return("")
-	gcp.On("LoadConfig", config.GlobalScope).Return(nil, errors.New("mock_test_load_config_error"))
-	bump.GitConfigParser = gcp
-
-	_, err := runBumpTest(t, testSuite, &bump.RunArgs{
-		VersionType:    testSuite.VersionType,
-		PrereleaseType: testSuite.PrereleaseType,
-		PassphrasePrompt: func() (string, error) {
-			return "", nil
-		},
-	})
-	//currently we continue through the loop instead of returning an error
-	a.ErrorContains(err, fmt.Sprintf("%s: mock_test_load_config_error", git.ErrStrLoadingConfiguration))
-}
-
-func TestBump_PassphraseGetShouldNotSignLoadConfigPasses(t *testing.T) {
+func TestBump_PassphraseGetSigningKeyMissing(t *testing.T) {
 	a := assert.New(t)
 
 	testSuite := testSuites["Go - Single Constant #2"]
 
+	// the configuration is merged from every scope already, so no other scope is read for the key
 	gcp := new(mocks.GitConfigParser)
 	defer gcp.AssertExpectations(t)
 	gcp.On("SetConfig", mock.AnythingOfType("*config.Config")).Return(nil)
 	gcp.On("GetSectionOption", "commit", "gpgsign").Return("true")
 	gcp.On("GetSectionOption", "user", "signingkey").Return("")
-	gcp.On("LoadConfig", config.GlobalScope).Return(config.NewConfig(), nil)
 	bump.GitConfigParser = gcp
 
 	_, err := runBumpTest(t, testSuite, &bump.RunArgs{
 		VersionType:    testSuite.VersionType,
 		PrereleaseType: testSuite.PrereleaseType,
 		PassphrasePrompt: func() (string, error) {
-			return "", nil
+			return "", errors.New("the passphrase is not prompted without a signing key")
 		},
 	})
-	//currently we continue through the loop instead of returning an error
 	a.Nil(err)
+	gcp.AssertNotCalled(t, "LoadConfig", mock.Anything)
 }
 
 func TestBump_DryRunRegex(t *testing.T) {
@@ -1539,7 +3979,9 @@ func TestBump_DryRunFails(t *testing.T) {
 func runBumpTest(t *testing.T, testSuite testBumpTestSuite, ra *bump.RunArgs) (*bump.Bump, error) {
 
 	m1 := new(mocks.Repository)
+	m1.On("Tags").Return(noTags, nil)
 	m2 := new(mocks.Worktree)
+	m2.On("Status").Return(gogit.Status{}, nil)
 
 	gitConfig := new(config.Config)
 	gitConfig.User.Name = git.Username
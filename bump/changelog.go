@@ -0,0 +1,123 @@
+package bump
+
+import (
+	"path"
+	"time"
+
+	"github.com/nidhhoggr/version-bump/changelog"
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/conventional"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+var (
+	ErrStrFormattedWritingChangelog = "writing changelog %v"
+)
+
+// writeChangelog prepends a section listing the conventional commits since the latest release tag to the changelog
+// of the released package, go module or repository, or promotes its Unreleased section.
+// The changelog file and the released section are returned.
+func (vbd *versionBumpData) writeChangelog() (string, string, error) {
+	cfg := vbd.bump.Changelog
+	file := vbd.changelogFile()
+
+	content, err := afero.ReadFile(vbd.bump.FS, file)
+	if err != nil {
+		if exists, _ := afero.Exists(vbd.bump.FS, file); exists || cfg.Unreleased {
+			return "", "", errors.Wrapf(err, ErrStrFormattedReadingAFile, file)
+		}
+		content = []byte{}
+	}
+
+	release := &changelog.Release{
+		Version: vbd.versionStr,
+		Date:    time.Now(),
+		Tag:     vbd.git.TagName(vbd.versionStr),
+		URL:     cfg.URL,
+		Entries: make([]changelog.Entry, 0),
+	}
+
+	var section string
+	if cfg.Unreleased {
+		content, section, err = changelog.Promote(content, release, vbd.runArgs.AllowEmptyChangelog)
+		if err != nil {
+			return "", "", errors.Wrapf(err, ErrStrFormattedWritingChangelog, file)
+		}
+	} else {
+		if err := vbd.collectChangelogEntries(release); err != nil {
+			return "", "", errors.Wrapf(err, ErrStrFormattedWritingChangelog, file)
+		}
+		section = changelog.Section(release)
+		content = changelog.Prepend(content, section)
+	}
+
+	console.Language("Changelog", vbd.runArgs.IsDryRun)
+	console.ChangelogSection(vbd.versionStr, file)
+
+	if !vbd.runArgs.IsDryRun {
+		if err := vbd.writeFile(file, string(content)); err != nil {
+			return "", "", errors.Wrapf(err, ErrStrFormattedWritingToFile, file)
+		}
+	}
+
+	return file, section, nil
+}
+
+// checkChangelog refuses to release when the Unreleased section to promote is missing or empty, before any file is modified
+func (vbd *versionBumpData) checkChangelog() error {
+	file := vbd.changelogFile()
+	content, err := afero.ReadFile(vbd.bump.FS, file)
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedReadingAFile, file)
+	}
+	if err := changelog.Check(content, vbd.runArgs.AllowEmptyChangelog); err != nil {
+		return errors.Wrapf(err, ErrStrFormattedWritingChangelog, file)
+	}
+	return nil
+}
+
+// collectChangelogEntries adds the conventional commits since the latest release tag to release
+func (vbd *versionBumpData) collectChangelogEntries(release *changelog.Release) error {
+	tag, err := vbd.git.LatestReachableTag()
+	if err != nil {
+		return err
+	}
+
+	commits, err := vbd.git.CommitsSince(tag, vbd.releaseDirectories())
+	if err != nil {
+		return err
+	}
+
+	if tag != nil {
+		release.PreviousTag = tag.Name
+	}
+	for _, commit := range commits {
+		if c, ok := conventional.Parse(commit.Message); ok {
+			release.Entries = append(release.Entries, changelog.Entry{Hash: commit.Hash.String(), Commit: c})
+		}
+	}
+
+	return nil
+}
+
+// changelogFile returns the path of the configured changelog in the directory of the released package, go module or repository
+func (vbd *versionBumpData) changelogFile() string {
+	file := vbd.bump.Changelog.File
+	if file == "" {
+		file = changelog.DefaultFile
+	}
+	return path.Join(vbd.changelogDir(), file)
+}
+
+// changelogDir returns the directory of the selected go module, the first directory of the selected package, or the directory of the .bump file
+func (vbd *versionBumpData) changelogDir() string {
+	if vbd.goModule != nil {
+		return vbd.goModule.Dir
+	} else if vbd.pkg != nil && len(vbd.pkg.Directories) > 0 {
+		return path.Clean(vbd.pkg.Directories[0])
+	} else if vbd.bump.Dir != "" {
+		return path.Clean(vbd.bump.Dir)
+	}
+	return "."
+}
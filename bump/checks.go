@@ -0,0 +1,71 @@
+package bump
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrFormattedDirtyWorktree     = "the working tree has uncommitted changes in %v, commit or stash them before releasing"
+	ErrStrFormattedBranchNotAllowed  = "releases are not allowed from branch %v, allowed branches are %v"
+	ErrStrFormattedBehindUpstream    = "HEAD is behind its upstream %v, pull before releasing"
+	ErrStrFormattedHeadAlreadyTagged = "HEAD is already released as %v"
+	ErrStrFormattedInvalidBranch     = "invalid branch pattern %v"
+)
+
+// checkRepository runs the pre-flight checks of the [checks] section which are not overridden by the run arguments
+func (vbd *versionBumpData) checkRepository() error {
+	checks := vbd.bump.Checks
+	ra := vbd.runArgs
+	gi := vbd.git
+
+	if checks.Clean && !ra.AllowDirty {
+		changes, err := gi.Changes()
+		if err != nil {
+			return err
+		} else if len(changes) > 0 {
+			return fmt.Errorf(ErrStrFormattedDirtyWorktree, strings.Join(changes, ", "))
+		}
+	}
+
+	if len(checks.Branches) > 0 && !ra.AllowAnyBranch {
+		branch, err := gi.Branch()
+		if err != nil {
+			return err
+		}
+		allowed := false
+		for _, pattern := range checks.Branches {
+			matched, err := path.Match(pattern, branch)
+			if err != nil {
+				return errors.Wrapf(err, ErrStrFormattedInvalidBranch, pattern)
+			}
+			allowed = allowed || matched
+		}
+		if !allowed {
+			return fmt.Errorf(ErrStrFormattedBranchNotAllowed, branch, strings.Join(checks.Branches, ", "))
+		}
+	}
+
+	if checks.Upstream && !ra.AllowBehind {
+		upstream, err := gi.BehindUpstream()
+		if err != nil {
+			return err
+		} else if upstream != "" {
+			return fmt.Errorf(ErrStrFormattedBehindUpstream, upstream)
+		}
+	}
+
+	if checks.Untagged && !ra.AllowTagged {
+		tag, err := gi.HeadReleaseTag()
+		if err != nil {
+			return err
+		} else if tag != nil {
+			return fmt.Errorf(ErrStrFormattedHeadAlreadyTagged, tag.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package bump
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/dependents"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/nidhhoggr/version-bump/langs/golang"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrStrFormattedUpdatingDependent        = "updating dependents in package %v"
+	ErrStrFormattedCascadingToPackage       = "releasing dependent package %v"
+	ErrStrFormattedUnresolvableGoTag        = "go cannot resolve tag %v of module %v, which must be tagged %v to be required"
+	ErrStrFormattedGoModuleWithoutMajorPath = "go module %v cannot be required at %v without the path %v"
+)
+
+// packageIdentity holds the names under which other packages can depend on a package
+type packageIdentity struct {
+	npmName  string
+	goModule string
+	// goModuleDir is the directory of the go.mod declaring goModule
+	goModuleDir string
+	crateName   string
+}
+
+// identityOf reads the npm, go module and crate names declared by the manifests in the directories of pkg
+func identityOf(afs afero.Fs, pkg *Package) packageIdentity {
+	id := packageIdentity{}
+	for _, dir := range pkg.Directories {
+		if content, err := afero.ReadFile(afs, path.Join(dir, dependents.PackageJSON)); err == nil && id.npmName == "" {
+			id.npmName = dependents.NpmName(content)
+		}
+		if content, err := afero.ReadFile(afs, path.Join(dir, golang.ModFile)); err == nil && id.goModule == "" {
+			id.goModule = modfile.ModulePath(content)
+			id.goModuleDir = path.Clean(dir)
+		}
+		if content, err := afero.ReadFile(afs, path.Join(dir, dependents.CargoToml)); err == nil && id.crateName == "" {
+			id.crateName = dependents.CrateName(content)
+		}
+	}
+	return id
+}
+
+// updateDependents rewrites the constraints of every package depending on the released package to its new version.
+// When cascading, each updated package is released with a patch version as well, and so are its own dependents.
+// The modified files and the tags of the cascaded releases are returned.
+func (vbd *versionBumpData) updateDependents(released map[string]bool) ([]string, []git.Tag, error) {
+	files := make([]string, 0)
+	tags := make([]git.Tag, 0)
+	released[vbd.pkg.Name] = true
+
+	id := identityOf(vbd.bump.FS, vbd.pkg)
+
+	for i := range vbd.bump.Packages {
+		dependent := &vbd.bump.Packages[i]
+		if dependent.Name == vbd.pkg.Name {
+			continue
+		}
+
+		changedFiles, err := vbd.updateDependentConstraints(dependent, id)
+		if err != nil {
+			return []string{}, []git.Tag{}, errors.Wrapf(err, ErrStrFormattedUpdatingDependent, dependent.Name)
+		}
+		files = appendUnique(files, changedFiles...)
+
+		if len(changedFiles) == 0 || !vbd.bump.Dependents.Cascade || released[dependent.Name] {
+			continue
+		}
+
+		cascadedFiles, cascadedTags, err := vbd.cascadeTo(dependent, released)
+		if err != nil {
+			return []string{}, []git.Tag{}, errors.Wrapf(err, ErrStrFormattedCascadingToPackage, dependent.Name)
+		}
+		files = appendUnique(files, cascadedFiles...)
+		tags = append(tags, cascadedTags...)
+	}
+
+	return files, tags, nil
+}
+
+// updateDependentConstraints rewrites the constraints on id in the manifests of dependent
+func (vbd *versionBumpData) updateDependentConstraints(dependent *Package, id packageIdentity) ([]string, error) {
+	files := make([]string, 0)
+	changes := make(map[string][]byte)
+
+	for _, dir := range dependent.Directories {
+		if id.npmName != "" {
+			filepath := path.Join(dir, dependents.PackageJSON)
+			if content, err := afero.ReadFile(vbd.bump.FS, filepath); err == nil {
+				newContent, changed, err := dependents.SetNpmDependency(content, id.npmName, vbd.versionStr)
+				if err != nil {
+					return []string{}, err
+				} else if changed {
+					changes[filepath] = newContent
+					files = append(files, filepath)
+					console.Language(dependent.Name, vbd.runArgs.IsDryRun)
+					console.DependencyUpdate(id.npmName, vbd.versionStr, filepath)
+				}
+			}
+		}
+		if id.goModule != "" {
+			filepath := path.Join(dir, golang.ModFile)
+			if content, err := afero.ReadFile(vbd.bump.FS, filepath); err == nil {
+				modVersion, err := vbd.goRequirementVersion(id, content)
+				if err != nil {
+					return []string{}, err
+				}
+				newContent, changed, err := golang.SetRequire(content, id.goModule, id.goModule, modVersion)
+				if err != nil {
+					return []string{}, err
+				} else if changed {
+					changes[filepath] = newContent
+					files = append(files, filepath)
+					console.Language(dependent.Name, vbd.runArgs.IsDryRun)
+					console.DependencyUpdate(id.goModule, modVersion, filepath)
+				}
+			}
+		}
+		if id.crateName != "" {
+			filepath := path.Join(dir, dependents.CargoToml)
+			if content, err := afero.ReadFile(vbd.bump.FS, filepath); err == nil {
+				newContent, changed := dependents.SetCrateDependency(content, id.crateName, vbd.versionStr)
+				if changed {
+					changes[filepath] = newContent
+					files = append(files, filepath)
+					console.Language(dependent.Name, vbd.runArgs.IsDryRun)
+					console.DependencyUpdate(id.crateName, vbd.versionStr, filepath)
+				}
+			}
+		}
+	}
+
+	if err := vbd.writeChanges(files, changes); err != nil {
+		return []string{}, err
+	}
+
+	return files, nil
+}
+
+// goRequirementVersion returns the version at which gomod requires the released go module of id. Go resolves it from the release
+// tag, which must be prefixed with the directory of the module, and from the module path, which must end with the major version
+// as migrated modules do. The version is not resolved when gomod does not require the module, or replaces it locally.
+func (vbd *versionBumpData) goRequirementVersion(id packageIdentity, gomod []byte) (string, error) {
+	modVersion := fmt.Sprintf("v%v", vbd.versionStr)
+	if _, required, err := golang.SetRequire(gomod, id.goModule, id.goModule, modVersion); err != nil || !required {
+		return modVersion, nil
+	} else if golang.ReplacesLocally(gomod, id.goModule) {
+		return modVersion, nil
+	}
+
+	module := golang.Module{Dir: id.goModuleDir, Path: id.goModule}
+	tagName := vbd.git.TagName(vbd.versionStr)
+	if tagVersion, ok := strings.CutPrefix(tagName, module.TagPrefix()); !ok || tagVersion != modVersion {
+		return "", fmt.Errorf(ErrStrFormattedUnresolvableGoTag, tagName, id.goModule, module.TagPrefix()+modVersion)
+	}
+
+	var major uint64
+	if _, err := fmt.Sscan(strings.TrimPrefix(semver.Major(modVersion), "v"), &major); err != nil {
+		return "", errors.Wrapf(err, ErrStrFormattedUpdatingGoModuleDependent, id.goModuleDir)
+	}
+	if majorPath := golang.ModulePathForMajor(id.goModule, major); majorPath != id.goModule {
+		return "", fmt.Errorf(ErrStrFormattedGoModuleWithoutMajorPath, id.goModule, modVersion, majorPath)
+	}
+
+	return modVersion, nil
+}
+
+// cascadeTo releases a patch version of pkg, tagged with its tag template, followed by its own dependents
+func (vbd *versionBumpData) cascadeTo(pkg *Package, released map[string]bool) ([]string, []git.Tag, error) {
+	// the release tags of pkg are those of its own tag template
+	gitInstance := *vbd.git
+	gitInstance.TagTemplate = pkg.TagTemplate
+	cascade := &versionBumpData{
+		bump: &Bump{
+			FS:            vbd.bump.FS,
+			Git:           &gitInstance,
+			WaitGroup:     new(sync.WaitGroup),
+			Configuration: pkg.Configuration,
+			Packages:      vbd.bump.Packages,
+			Dependents:    vbd.bump.Dependents,
+		},
+		versionsDetected: NewVersionDetector(),
+		runArgs: &RunArgs{
+			ConfirmationPrompt: vbd.runArgs.ConfirmationPrompt,
+			VersionType:        version.Patch,
+			IsDryRun:           vbd.runArgs.IsDryRun,
+		},
+		git:           &gitInstance,
+		configuration: pkg.Configuration,
+		pkg:           pkg,
+		originals:     vbd.originals,
+	}
+
+	if err := cascade.loadReleaseTags(); err != nil {
+		return []string{}, []git.Tag{}, err
+	}
+
+	files, err := cascade.bumpVersions()
+	if err != nil {
+		return []string{}, []git.Tag{}, err
+	}
+
+	tagName := git.TagName(pkg.TagTemplate, cascade.versionStr)
+	message, err := vbd.git.TagMessage(&git.Release{
+		Version:    cascade.versionStr,
+		OldVersion: cascade.oldVersion(),
+		Files:      files,
+		Date:       time.Now(),
+		Tag:        tagName,
+	})
+	if err != nil {
+		return []string{}, []git.Tag{}, err
+	}
+
+	tags := []git.Tag{{
+		Name:    tagName,
+		Message: message,
+	}}
+
+	dependentFiles, dependentTags, err := cascade.updateDependents(released)
+	if err != nil {
+		return []string{}, []git.Tag{}, err
+	}
+
+	return appendUnique(files, dependentFiles...), append(tags, dependentTags...), nil
+}
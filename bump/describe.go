@@ -0,0 +1,105 @@
+package bump
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrDescribing = "describing HEAD"
+
+	ErrStrFormattedRenderingDescribeFormat = "rendering describe format %q"
+	ErrStrFormattedUnsupportedDescribeType = "describe supports major, minor and patch version types, %v was requested"
+)
+
+// DefaultDescribeFormat produces development versions such as 1.4.0-dev.7+g3f2a1bc, or 1.4.0-dev.7+g3f2a1bc.dirty
+const DefaultDescribeFormat = "{{.Next}}-dev.{{.Distance}}+g{{.Hash}}{{if .Dirty}}.dirty{{end}}"
+
+// describedVersion is rendered by the describe format
+type describedVersion struct {
+	// Version is the version of the latest release tag, or 0.0.0
+	Version string
+	// Next is the version following Version with the requested version type
+	Next string
+	// Tag is the name of the latest release tag, empty when there is none
+	Tag string
+	// Distance is the number of commits since Tag
+	Distance int
+	// Hash and FullHash are the abbreviated and full hashes of HEAD
+	Hash     string
+	FullHash string
+	// Dirty reports whether tracked files are modified
+	Dirty bool
+}
+
+// Describe returns the development version of HEAD, following the version of the latest reachable release tag.
+// HEAD is described with the released version itself when it is the tagged commit and the working tree is clean.
+func (b *Bump) Describe(ra *RunArgs) (string, error) {
+	vbd := &versionBumpData{
+		bump:    b,
+		runArgs: ra,
+	}
+
+	if err := vbd.selectRelease(); err != nil {
+		return "", err
+	}
+
+	versionType := ra.VersionType
+	if versionType == version.NotAVersion {
+		versionType = version.Patch
+	} else if versionType == version.Auto {
+		return "", fmt.Errorf(ErrStrFormattedUnsupportedDescribeType, version.TypeString(versionType))
+	}
+
+	description, err := vbd.git.Describe()
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrDescribing)
+	}
+
+	data := &describedVersion{
+		Version:  initialVersion,
+		Distance: description.Distance,
+		Hash:     description.ShortHash(),
+		FullHash: description.Hash.String(),
+		Dirty:    description.Dirty,
+	}
+	if description.Tag != nil {
+		data.Version = description.Tag.Version
+		data.Tag = description.Tag.Name
+		if data.Distance == 0 && !data.Dirty {
+			return data.Version, nil
+		}
+	}
+
+	next, err := version.New(data.Version)
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrDescribing)
+	}
+	if err := next.Increment(versionType, version.NotAPrerelease, ""); err != nil {
+		return "", errors.Wrap(err, ErrStrDescribing)
+	}
+	data.Next = next.String()
+
+	format := ra.DescribeFormat
+	if format == "" {
+		format = b.DescribeConfig.Format
+	}
+	if format == "" {
+		format = DefaultDescribeFormat
+	}
+
+	tpl, err := template.New("describe").Option("missingkey=error").Parse(format)
+	if err != nil {
+		return "", errors.Wrapf(err, ErrStrFormattedRenderingDescribeFormat, format)
+	}
+	var sb strings.Builder
+	if err := tpl.Execute(&sb, data); err != nil {
+		return "", errors.Wrapf(err, ErrStrFormattedRenderingDescribeFormat, format)
+	}
+
+	return sb.String(), nil
+}
@@ -1,9 +1,12 @@
 package bump
 
 import (
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nidhhoggr/version-bump/changelog"
 	"github.com/nidhhoggr/version-bump/git"
 	"github.com/nidhhoggr/version-bump/gpg"
 	"github.com/nidhhoggr/version-bump/langs"
+	"github.com/nidhhoggr/version-bump/langs/golang"
 	"github.com/nidhhoggr/version-bump/version"
 	"github.com/spf13/afero"
 	"net/http"
@@ -12,6 +15,8 @@ import (
 
 const (
 	Version string = "2.1.3"
+	// ConfigFile is the name of the project configuration file
+	ConfigFile string = ".bump"
 )
 
 var GhRepoName = "nidhhoggr/version-bump"
@@ -28,31 +33,84 @@ type ReleaseGetterInterface interface {
 }
 
 type Bump struct {
-	FS                      afero.Fs
-	Git                     *git.Instance
-	errChanVersionGathering chan error
-	errChanPostProcessing   chan error
-	WaitGroup               *sync.WaitGroup
-	Configuration           Configuration
-	mutex                   sync.Mutex
+	FS  afero.Fs
+	Git *git.Instance
+	// Dir is the directory of the .bump file relative to the root of FS, "." for the root of the repository
+	Dir string
+	// versionsGathered is closed once every file is read, and errVersionGathering then cancels every replacement
+	versionsGathered      chan struct{}
+	errVersionGathering   error
+	errChanPostProcessing chan error
+	WaitGroup             *sync.WaitGroup
+	Configuration         Configuration
+	Packages              []Package
+	Dependents            langs.DependentsConfig
+	Changelog             changelog.Config
+	Push                  git.PushConfig
+	Checks                git.ChecksConfig
+	TagOnly               bool
+	DescribeConfig        git.DescribeConfig
+	Branches              git.BranchesConfig
+	mutex                 sync.Mutex
 }
 
 type Configuration []langs.Config
 
+// Package is an independently versioned part of a repository configured by a [[package]] section
+type Package struct {
+	Name          string
+	TagTemplate   string
+	Directories   []string
+	Configuration Configuration
+}
+
 type RunArgs struct {
 	ConfirmationPrompt func(string, string, string) (bool, error)
 	PassphrasePrompt   func() (string, error)
 	PrereleaseMetadata string
-	VersionType        version.Type
-	PrereleaseType     version.PrereleaseType
-	IsDryRun           bool
+	// Package is the name of the [[package]] to release
+	Package string
+	// GoModule is the directory of the go module to release, tagged with the directory as a prefix
+	GoModule       string
+	VersionType    version.Type
+	PrereleaseType version.PrereleaseType
+	IsDryRun       bool
+	// VerifyAPI refuses version types smaller than the one required by the go API changes since the latest release tag
+	VerifyAPI bool
+	// AllowEmptyChangelog promotes an empty Unreleased changelog section instead of failing
+	AllowEmptyChangelog bool
+	// Push pushes the current branch and the release tags to the configured remote
+	Push bool
+	// AllowDirty, AllowAnyBranch, AllowBehind and AllowTagged override the pre-flight checks of the [checks] section
+	AllowDirty     bool
+	AllowAnyBranch bool
+	AllowBehind    bool
+	AllowTagged    bool
+	// TagOnly reads the version from the highest reachable tag and only tags HEAD, without changing any file
+	TagOnly bool
+	// DescribeFormat overrides the format of the [describe] section
+	DescribeFormat string
 }
 
 type versionBumpData struct {
-	bump             *Bump
+	bump *Bump
+	// git and configuration are those of the Bump, scoped to the selected package or go module
+	git              *git.Instance
+	configuration    Configuration
 	versionsDetected VersionsDetected
 	runArgs          *RunArgs
+	goModule         *golang.Module
+	pkg              *Package
 	versionStr       string
+	goModules        []golang.Module
+	releaseTags      []git.ReleaseTag
+	// originals are shared with the releases of cascading dependents, which are part of the same commit
+	originals *originalFiles
+	// prereleaseIdentifier names branch prereleases, and prereleaseCommits is their counter when it is the number of commits
+	prereleaseIdentifier string
+	prereleaseCommits    int
+	// untaggedCommits are the conventional commits of an untagged repository whose version type depends on the version of the files
+	untaggedCommits []*object.Commit
 }
 
 type stringedMap map[string]int
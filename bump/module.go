@@ -0,0 +1,258 @@
+package bump
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/nidhhoggr/version-bump/langs/golang"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrStrDiscoveringGoModules = "discovering go modules"
+
+	ErrStrFormattedMigratingGoModule         = "migrating go module at %v"
+	ErrStrFormattedRewritingImportsIn        = "rewriting imports in %v"
+	ErrStrFormattedGoModuleNotFound          = "no go module found in directory %v"
+	ErrStrFormattedUpdatingGoModuleDependent = "updating requirements of go module at %v"
+)
+
+// discoverGoModules returns the modules of a go.work workspace, or every go.mod in the repository otherwise
+func discoverGoModules(afs afero.Fs) ([]golang.Module, error) {
+	modules := make([]golang.Module, 0)
+	dirs := make([]string, 0)
+
+	gowork, err := afero.ReadFile(afs, golang.WorkFile)
+	if err == nil {
+		dirs, err = golang.WorkspaceDirs(gowork)
+		if err != nil {
+			return modules, errors.Wrap(err, ErrStrDiscoveringGoModules)
+		}
+	} else {
+		err = walkModule(afs, ".", func(dir string, info os.FileInfo) error {
+			if !info.IsDir() && info.Name() == golang.ModFile {
+				dirs = append(dirs, path.Dir(dir))
+			}
+			return nil
+		}, false)
+		if err != nil {
+			return modules, errors.Wrap(err, ErrStrDiscoveringGoModules)
+		}
+	}
+
+	for _, dir := range dirs {
+		gomod, err := afero.ReadFile(afs, path.Join(dir, golang.ModFile))
+		if err != nil {
+			return modules, errors.Wrap(err, ErrStrDiscoveringGoModules)
+		}
+		modules = append(modules, golang.Module{
+			Dir:  dir,
+			Path: modfile.ModulePath(gomod),
+		})
+	}
+
+	return modules, nil
+}
+
+// selectGoModule scopes the release to the go module in dir, tagging releases with the module's tag prefix
+func (vbd *versionBumpData) selectGoModule(dir string) error {
+	dir = path.Clean(dir)
+
+	modules, err := discoverGoModules(vbd.bump.FS)
+	if err != nil {
+		return err
+	}
+
+	for i := range modules {
+		if modules[i].Dir == dir {
+			vbd.goModule = &modules[i]
+			vbd.goModules = modules
+			vbd.git.TagTemplate = modules[i].TagPrefix() + git.DefaultTagTemplate
+			vbd.configuration = vbd.configuration.scopedTo(dir)
+			return nil
+		}
+	}
+
+	return fmt.Errorf(ErrStrFormattedGoModuleNotFound, dir)
+}
+
+// migratesModule reports whether an enabled language migrates the go module, which is migrated once however many do
+func (c Configuration) migratesModule() bool {
+	for i := range c {
+		if c[i].Enabled && c[i].MigrateModule {
+			return true
+		}
+	}
+	return false
+}
+
+// migrateGoModule adds or updates the /vN suffix of the module declared in modDir when the major version changes.
+// Imports of the module's own packages are rewritten as well. The modified files and the new module path are returned.
+func (vbd *versionBumpData) migrateGoModule(modDir string) ([]string, string, error) {
+	modFilePath := path.Join(modDir, golang.ModFile)
+
+	gomod, err := afero.ReadFile(vbd.bump.FS, modFilePath)
+	if err != nil {
+		return []string{}, "", errors.Wrapf(err, ErrStrFormattedMigratingGoModule, modDir)
+	}
+
+	oldPath := modfile.ModulePath(gomod)
+	major := semver.Major(fmt.Sprintf("v%v", vbd.versionStr))
+	if major == semver.Major(fmt.Sprintf("v%v", vbd.oldVersion())) {
+		return []string{}, oldPath, nil
+	}
+	major = strings.TrimPrefix(major, "v")
+
+	var majorNumber uint64
+	if _, err = fmt.Sscan(major, &majorNumber); err != nil {
+		return []string{}, "", errors.Wrapf(err, ErrStrFormattedMigratingGoModule, modDir)
+	}
+
+	newPath := golang.ModulePathForMajor(oldPath, majorNumber)
+	if oldPath == "" || newPath == oldPath {
+		return []string{}, oldPath, nil
+	}
+
+	console.Language(golang.Name, vbd.runArgs.IsDryRun)
+	console.ModulePathUpdate(oldPath, newPath, modFilePath)
+
+	newGomod, err := golang.SetModulePath(gomod, newPath)
+	if err != nil {
+		return []string{}, "", errors.Wrapf(err, ErrStrFormattedMigratingGoModule, modDir)
+	}
+
+	changes, files, err := vbd.rewriteModuleImports(modDir, oldPath, newPath)
+	if err != nil {
+		return []string{}, "", errors.Wrapf(err, ErrStrFormattedMigratingGoModule, modDir)
+	}
+	changes[modFilePath] = newGomod
+	files = append([]string{modFilePath}, files...)
+
+	if err = vbd.writeChanges(files, changes); err != nil {
+		return []string{}, "", err
+	}
+
+	return files, newPath, nil
+}
+
+// updateGoModuleDependents points the requirements of every other module in the repository
+// at the new version, and new path, of the released module
+func (vbd *versionBumpData) updateGoModuleDependents(newPath string) ([]string, error) {
+	files := make([]string, 0)
+	oldPath := vbd.goModule.Path
+	modVersion := fmt.Sprintf("v%v", vbd.versionStr)
+
+	for _, dependent := range vbd.goModules {
+		if dependent.Dir == vbd.goModule.Dir {
+			continue
+		}
+
+		modFilePath := path.Join(dependent.Dir, golang.ModFile)
+		gomod, err := afero.ReadFile(vbd.bump.FS, modFilePath)
+		if err != nil {
+			return []string{}, errors.Wrapf(err, ErrStrFormattedUpdatingGoModuleDependent, dependent.Dir)
+		}
+
+		newGomod, required, err := golang.SetRequire(gomod, oldPath, newPath, modVersion)
+		if err != nil {
+			return []string{}, errors.Wrapf(err, ErrStrFormattedUpdatingGoModuleDependent, dependent.Dir)
+		} else if !required {
+			continue
+		}
+
+		console.Language(golang.Name, vbd.runArgs.IsDryRun)
+		console.ModulePathUpdate(oldPath, fmt.Sprintf("%s %s", newPath, modVersion), modFilePath)
+
+		changes := map[string][]byte{
+			modFilePath: newGomod,
+		}
+		changedFiles := []string{modFilePath}
+
+		if oldPath != newPath {
+			importChanges, importFiles, err := vbd.rewriteModuleImports(dependent.Dir, oldPath, newPath)
+			if err != nil {
+				return []string{}, errors.Wrapf(err, ErrStrFormattedUpdatingGoModuleDependent, dependent.Dir)
+			}
+			for f, content := range importChanges {
+				changes[f] = content
+			}
+			changedFiles = append(changedFiles, importFiles...)
+		}
+
+		if err = vbd.writeChanges(changedFiles, changes); err != nil {
+			return []string{}, err
+		}
+		files = append(files, changedFiles...)
+	}
+
+	return files, nil
+}
+
+// rewriteModuleImports computes the rewritten content of every go file of the module in modDir importing oldPath
+func (vbd *versionBumpData) rewriteModuleImports(modDir string, oldPath string, newPath string) (map[string][]byte, []string, error) {
+	changes := make(map[string][]byte)
+	files := make([]string, 0)
+
+	err := walkModule(vbd.bump.FS, modDir, func(filepath string, info os.FileInfo) error {
+		if info.IsDir() || !strings.HasSuffix(filepath, ".go") {
+			return nil
+		}
+		src, err := afero.ReadFile(vbd.bump.FS, filepath)
+		if err != nil {
+			return errors.Wrapf(err, ErrStrFormattedReadingAFile, filepath)
+		}
+		rewritten, changed, err := golang.RewriteImports(src, oldPath, newPath)
+		if err != nil {
+			return errors.Wrapf(err, ErrStrFormattedRewritingImportsIn, filepath)
+		}
+		if changed {
+			console.ModulePathUpdate(oldPath, newPath, filepath)
+			changes[filepath] = rewritten
+			files = append(files, filepath)
+		}
+		return nil
+	}, true)
+
+	return changes, files, err
+}
+
+func (vbd *versionBumpData) writeChanges(files []string, changes map[string][]byte) error {
+	if vbd.runArgs.IsDryRun {
+		return nil
+	}
+	for _, f := range files {
+		if err := vbd.writeFile(f, string(changes[f])); err != nil {
+			return errors.Wrapf(err, ErrStrFormattedWritingToFile, f)
+		}
+	}
+	return nil
+}
+
+// walkModule visits the files of the module in modDir, skipping vendor, testdata and hidden directories.
+// Nested modules are skipped as well when skipNestedModules is set.
+func walkModule(afs afero.Fs, modDir string, walkFn func(string, os.FileInfo) error, skipNestedModules bool) error {
+	return afero.Walk(afs, modDir, func(filepath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && filepath != modDir {
+			if info.Name() == "vendor" || info.Name() == "testdata" || strings.HasPrefix(info.Name(), ".") {
+				return fs.SkipDir
+			}
+			if skipNestedModules {
+				if exists, _ := afero.Exists(afs, path.Join(filepath, golang.ModFile)); exists {
+					return fs.SkipDir
+				}
+			}
+		}
+		return walkFn(filepath, info)
+	})
+}
@@ -0,0 +1,74 @@
+package bump
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+var (
+	ErrStrRollingBackChanges = "rolling back the changed files"
+)
+
+// originalFiles keeps the content of the files changed by a release from before their first change, nil for created files
+type originalFiles struct {
+	mutex    sync.Mutex
+	contents map[string][]byte
+}
+
+func newOriginalFiles() *originalFiles {
+	return &originalFiles{contents: make(map[string][]byte)}
+}
+
+// writeFile writes content to file, keeping its original content for rollback
+func (vbd *versionBumpData) writeFile(file string, content string) error {
+	if vbd.originals != nil {
+		vbd.originals.mutex.Lock()
+		if _, recorded := vbd.originals.contents[file]; !recorded {
+			original, err := afero.ReadFile(vbd.bump.FS, file)
+			if err != nil {
+				vbd.originals.contents[file] = nil
+			} else {
+				vbd.originals.contents[file] = append([]byte{}, original...)
+			}
+		}
+		vbd.originals.mutex.Unlock()
+	}
+	return writeFile(vbd.bump.FS, file, content)
+}
+
+// rollback restores the files changed by the release to their original content, and removes the files it created
+func (vbd *versionBumpData) rollback() error {
+	if vbd.originals == nil {
+		return nil
+	}
+	vbd.originals.mutex.Lock()
+	defer vbd.originals.mutex.Unlock()
+
+	for file, original := range vbd.originals.contents {
+		var err error
+		if original == nil {
+			err = vbd.bump.FS.Remove(file)
+		} else {
+			err = writeFile(vbd.bump.FS, file, string(original))
+		}
+		if err != nil {
+			return errors.Wrapf(err, ErrStrFormattedWritingToFile, file)
+		}
+	}
+	vbd.originals.contents = make(map[string][]byte)
+
+	return nil
+}
+
+// rollbackOnError rolls back the files changed by the release when err is not nil, and returns err
+func (vbd *versionBumpData) rollbackOnError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if rollbackErr := vbd.rollback(); rollbackErr != nil {
+		return errors.Wrap(rollbackErr, ErrStrRollingBackChanges)
+	}
+	return err
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/nidhhoggr/version-bump/git"
 	"github.com/nidhhoggr/version-bump/langs"
 	"github.com/nidhhoggr/version-bump/langs/golang"
@@ -96,10 +98,17 @@ func main() {
 	},
 }
 
+// noTags returns an empty tag iterator for repository mocks
+func noTags() storer.ReferenceIter {
+	return storer.NewReferenceSliceIter(nil)
+}
+
 func getBumpInstance(testSuite testBumpTestSuite) *bump.Bump {
 
 	m1 := new(mocks.Repository)
+	m1.On("Tags").Return(noTags, nil)
 	m2 := new(mocks.Worktree)
+	m2.On("Status").Return(gogit.Status{}, nil)
 
 	gitConfig := &config.Config{}
 	gitConfig.User.Name = git.Username
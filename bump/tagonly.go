@@ -0,0 +1,78 @@
+package bump
+
+import (
+	"fmt"
+
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrReadingTaggedVersion = "reading the version of the highest reachable tag"
+
+	ErrStrFormattedVersionUnchanged = "version %v is unchanged"
+)
+
+// initialVersion is incremented when no release tag is reachable in tag-only mode
+const initialVersion = "0.0.0"
+
+// tagRelease increments the version of the highest release tag reachable from HEAD and tags HEAD with it.
+// No file is changed and nothing is committed.
+func (vbd *versionBumpData) tagRelease() error {
+	b := vbd.bump
+	ra := vbd.runArgs
+
+	tag, err := vbd.git.LatestReachableTag()
+	if err != nil {
+		return errors.Wrap(err, ErrStrReadingTaggedVersion)
+	}
+	current := initialVersion
+	if tag != nil {
+		current = tag.Version
+	}
+
+	v, err := version.New(current)
+	if err != nil {
+		return errors.Wrap(err, ErrStrReadingTaggedVersion)
+	}
+	unchanged, err := vbd.incrementAndCompareVersions(v)
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedBumpingVersion, current)
+	} else if unchanged {
+		return fmt.Errorf(ErrStrFormattedVersionUnchanged, current)
+	}
+
+	if err := vbd.checkReleaseTags(); err != nil {
+		return err
+	}
+
+	release := &git.Release{
+		Version:    vbd.versionStr,
+		OldVersion: current,
+	}
+	console.TaggingHead(current, vbd.versionStr, vbd.git.TagName(vbd.versionStr), ra.IsDryRun)
+
+	if ra.IsDryRun {
+		return vbd.createReleaseBranch()
+	}
+
+	gpgEntity, err := vbd.gpgEntity()
+	if err != nil {
+		return err
+	}
+
+	if err := vbd.git.Tag(release, gpgEntity); err != nil {
+		return err
+	}
+
+	if ra.Push {
+		console.PushingChanges(b.Push.RemoteName())
+		if err := vbd.git.Push(&b.Push, release); err != nil {
+			return err
+		}
+	}
+
+	return vbd.createReleaseBranch()
+}
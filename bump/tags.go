@@ -0,0 +1,105 @@
+package bump
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrReadingReleaseTags = "reading the release tags"
+
+	ErrStrFormattedVersionAlreadyTagged = "version %v is already released as %v"
+	ErrStrFormattedVersionBelowRelease  = "version %v sorts below the latest release %v on the same line"
+)
+
+// loadReleaseTags reads the release tags once, before any file is changed
+func (vbd *versionBumpData) loadReleaseTags() error {
+	tags, err := vbd.git.ReleaseTags()
+	if err != nil {
+		return errors.Wrap(err, ErrStrReadingReleaseTags)
+	}
+	vbd.releaseTags = tags
+	return nil
+}
+
+// checkReleaseTags refuses a new version which is already tagged, or which sorts below the latest release tag on its line.
+// The line of a patch is its minor version, the line of a minor is its major version, and majors share a single line.
+func (vbd *versionBumpData) checkReleaseTags() error {
+	newVersion, err := semver.NewVersion(vbd.versionStr)
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedBumpingVersion, vbd.versionStr)
+	}
+
+	// release tags are sorted from the highest to the lowest version
+	for _, tag := range vbd.releaseTags {
+		tagVersion, err := semver.NewVersion(tag.Version)
+		if err != nil || !sameLine(newVersion, tagVersion) {
+			continue
+		}
+		switch tagVersion.Compare(newVersion) {
+		case 0:
+			return fmt.Errorf(ErrStrFormattedVersionAlreadyTagged, vbd.versionStr, tag.Name)
+		case 1:
+			return fmt.Errorf(ErrStrFormattedVersionBelowRelease, vbd.versionStr, tag.Name)
+		}
+		return nil
+	}
+
+	return nil
+}
+
+// sameLine reports whether other belongs to the release line of v
+func sameLine(v, other *semver.Version) bool {
+	switch {
+	case v.Patch() > 0:
+		return other.Major() == v.Major() && other.Minor() == v.Minor()
+	case v.Minor() > 0:
+		return other.Major() == v.Major()
+	}
+	return true
+}
+
+// skipTaggedPrereleases moves the counter ending the prerelease of v past the counters of the tags of the same prerelease,
+// so that numbers are never reused, e.g. 1.4.0-rc.0 becomes 1.4.0-rc.2 when 1.4.0-rc.1 is tagged
+func (vbd *versionBumpData) skipTaggedPrereleases(v *version.Version) error {
+	current, err := semver.NewVersion(v.String())
+	if err != nil {
+		return err
+	}
+	prefix, counter, ok := prereleaseCounter(current.Prerelease())
+	if !ok {
+		return nil
+	}
+
+	next := counter
+	for _, tag := range vbd.releaseTags {
+		tagVersion, err := semver.NewVersion(tag.Version)
+		if err != nil || tagVersion.Major() != current.Major() || tagVersion.Minor() != current.Minor() || tagVersion.Patch() != current.Patch() {
+			continue
+		}
+		tagPrefix, tagCounter, ok := prereleaseCounter(tagVersion.Prerelease())
+		if ok && tagPrefix == prefix && tagCounter >= next {
+			next = tagCounter + 1
+		}
+	}
+
+	if next == counter {
+		return nil
+	}
+	return v.SetPrereleaseString(fmt.Sprintf("%s%d", prefix, next))
+}
+
+// prereleaseCounter splits a prerelease such as rc.3 into rc. and 3
+func prereleaseCounter(prerelease string) (string, int64, bool) {
+	i := strings.LastIndex(prerelease, ".")
+	counter, err := strconv.ParseInt(prerelease[i+1:], 10, 64)
+	if i < 0 || err != nil {
+		return "", 0, false
+	}
+	return prerelease[:i+1], counter, true
+}
@@ -0,0 +1,58 @@
+package bump
+
+import (
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/nidhhoggr/version-bump/git"
+)
+
+// Undo reverts the release made by the latest bump: the release tag pointing at HEAD, the tags of the dependents released along
+// with it and its alias tags are deleted, and the branch is moved back to the parent of the release commit along with the index
+// and the working tree. It refuses when HEAD is not a release commit, or when the configured remote already has one of its tags.
+func (b *Bump) Undo(ra *RunArgs) error {
+	vbd := &versionBumpData{
+		bump:    b,
+		runArgs: ra,
+	}
+
+	if err := vbd.selectRelease(); err != nil {
+		return err
+	}
+
+	release, err := vbd.git.HeadRelease()
+	if err != nil {
+		return err
+	}
+	// the dependents released along with it are tagged on the same commit
+	tagTemplates := make([]string, 0)
+	for i := range b.Packages {
+		tagTemplates = append(tagTemplates, b.Packages[i].TagTemplate)
+	}
+	tags, err := vbd.git.ReleaseTagsAt(release, tagTemplates)
+	if err != nil {
+		return err
+	}
+	aliases, err := vbd.git.AliasTagsAt(release)
+	if err != nil {
+		return err
+	}
+
+	if err := vbd.git.CheckUnpushed(&b.Push, release, tags, aliases); err != nil {
+		return err
+	}
+
+	console.UndoingRelease(append([]string{release.Name}, tags...), aliases, release.Commit.Hash.String()[:git.ShortHashLength], ra.IsDryRun)
+	if ra.IsDryRun {
+		return nil
+	}
+
+	// restored alias tags are signed like the original ones
+	var gpgEntity *openpgp.Entity
+	if len(aliases) > 0 {
+		if gpgEntity, err = vbd.gpgEntity(); err != nil {
+			return err
+		}
+	}
+
+	return vbd.git.Undo(release, tags, aliases, gpgEntity)
+}
@@ -2,14 +2,19 @@ package bump
 
 import (
 	"bufio"
+	"fmt"
 	"os"
 	"path"
+	"regexp"
 	"strings"
 
+	"github.com/nidhhoggr/version-bump/version"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 )
 
+var singleVersionRegex = regexp.MustCompile(fmt.Sprintf("^%v$", version.Regex))
+
 func getFiles(fs afero.Fs, dir string, excludeFiles []string) ([]string, error) {
 	res := make([]string, 0)
 
@@ -35,15 +40,23 @@ main:
 
 func filterFiles(configNames []string, files []string) []string {
 	res := make([]string, 0)
+main:
 	for _, f := range files {
 		for _, n := range configNames {
 			if strings.HasPrefix(n, "*.") {
 				if strings.HasSuffix(f, strings.TrimPrefix(n, "*")) {
 					res = append(res, f)
+					continue main
+				}
+			} else if strings.ContainsAny(n, "*?[") {
+				if matched, _ := path.Match(n, f); matched {
+					res = append(res, f)
+					continue main
 				}
 			} else {
 				if strings.HasSuffix(f, n) {
 					res = append(res, f)
+					continue main
 				}
 			}
 		}
@@ -88,3 +101,42 @@ func writeFile(fs afero.Fs, filepath string, content string) error {
 
 	return nil
 }
+
+func appendUnique(list []string, values ...string) []string {
+main:
+	for _, v := range values {
+		for _, l := range list {
+			if l == v {
+				continue main
+			}
+		}
+		list = append(list, v)
+	}
+	return list
+}
+
+// lineAt returns the line of content containing the byte at offset
+func lineAt(content []byte, offset int) string {
+	start := strings.LastIndexByte(string(content[:offset]), '\n') + 1
+	end := strings.IndexByte(string(content[offset:]), '\n')
+	if end == -1 {
+		return string(content[start:])
+	}
+	return strings.TrimRight(string(content[start:offset+end]), "\r")
+}
+
+// checkSingleVersion ensures the only non-blank line of a file is a version
+func checkSingleVersion(filepath string, lines []string) error {
+	found := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if found || !singleVersionRegex.MatchString(trimmed) {
+			return fmt.Errorf(ErrStrFormattedUnexpectedContentInVersionFile, filepath, i+1, line)
+		}
+		found = true
+	}
+	return nil
+}
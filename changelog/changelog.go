@@ -0,0 +1,257 @@
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/nidhhoggr/version-bump/conventional"
+)
+
+const (
+	// DefaultFile is the changelog updated when no file is configured
+	DefaultFile = "CHANGELOG.md"
+	// DateFormat is the format of release dates in section headers
+	DateFormat = "2006-01-02"
+
+	title = "# Changelog"
+)
+
+// Groups are the titles of the sections listing each conventional commit type, in the order they are rendered.
+// Commits of other types are left out of the changelog.
+var Groups = []struct {
+	Type  string
+	Title string
+}{
+	{Type: conventional.Feature, Title: "Features"},
+	{Type: conventional.Fix, Title: "Bug Fixes"},
+	{Type: "perf", Title: "Performance Improvements"},
+	{Type: "revert", Title: "Reverts"},
+	{Type: "refactor", Title: "Code Refactoring"},
+	{Type: "docs", Title: "Documentation"},
+}
+
+var (
+	ErrStrNoUnreleasedSection    = "no Unreleased section found in the changelog"
+	ErrStrEmptyUnreleasedSection = "the Unreleased section of the changelog is empty"
+)
+
+var (
+	issueRegex       = regexp.MustCompile(`(^|[\s(])#([0-9]+)\b`)
+	issueFooters     = []string{"Refs", "Closes", "Fixes", "Resolves"}
+	sectionHeadRegex = regexp.MustCompile(`^## `)
+
+	unreleasedHeadRegex = regexp.MustCompile(`(?i)^## \[unreleased\]`)
+	linkRegex           = regexp.MustCompile(`^\[[^\]]+\]:\s`)
+	unreleasedLinkRegex = regexp.MustCompile(`(?i)^(\[unreleased\]):\s*(\S+)/compare/(\S+)\.\.\.HEAD\s*$`)
+)
+
+// Config used to parse the [changelog] section of the .bump toml file
+type Config struct {
+	Enabled bool
+	// File is relative to the released package or go module, default CHANGELOG.md
+	File string
+	// URL of the repository used to link commits, issues and releases, e.g. https://github.com/acme/app
+	URL string
+	// TagMessage uses the rendered section as the message of the release tag
+	TagMessage bool `toml:"tag_message"`
+	// Unreleased promotes the hand-written Unreleased section of a Keep-a-Changelog file instead of generating a section from commits
+	Unreleased bool
+}
+
+// Entry is a commit listed in a changelog section
+type Entry struct {
+	Hash   string
+	Commit *conventional.Commit
+}
+
+// Release describes the changelog section of a new version
+type Release struct {
+	Version     string
+	Date        time.Time
+	Tag         string
+	PreviousTag string
+	URL         string
+	Entries     []Entry
+}
+
+// Section renders the changelog section of a release, listing breaking changes first and then every commit grouped by type
+func Section(r *Release) string {
+	var sb strings.Builder
+
+	header := fmt.Sprintf("[%s]", r.Version)
+	if r.URL != "" && r.PreviousTag != "" {
+		header = fmt.Sprintf("[%s](%s/compare/%s...%s)", r.Version, r.URL, r.PreviousTag, r.Tag)
+	} else if r.URL != "" {
+		header = fmt.Sprintf("[%s](%s/releases/tag/%s)", r.Version, r.URL, r.Tag)
+	}
+	sb.WriteString(fmt.Sprintf("## %s - %s\n", header, r.Date.Format(DateFormat)))
+
+	breaking := make([]string, 0)
+	for _, e := range r.Entries {
+		if !e.Commit.Breaking {
+			continue
+		}
+		description := e.Commit.Description
+		for _, f := range e.Commit.Footers {
+			if f.Token == conventional.BreakingChange {
+				description = f.Value
+			}
+		}
+		breaking = append(breaking, r.line(e, description))
+	}
+	writeGroup(&sb, "BREAKING CHANGES", breaking)
+
+	for _, g := range Groups {
+		lines := make([]string, 0)
+		for _, e := range r.Entries {
+			if e.Commit.Type == g.Type {
+				lines = append(lines, r.line(e, e.Commit.Description))
+			}
+		}
+		writeGroup(&sb, g.Title, lines)
+	}
+
+	return sb.String()
+}
+
+// line renders an entry as a list item linking its commit and the issues it references
+func (r *Release) line(e Entry, description string) string {
+	item := r.linkIssues(strings.ReplaceAll(strings.TrimSpace(description), "\n", " "))
+	if e.Commit.Scope != "" {
+		item = fmt.Sprintf("**%s:** %s", e.Commit.Scope, item)
+	}
+
+	shortHash := e.Hash
+	if len(shortHash) > 7 {
+		shortHash = shortHash[:7]
+	}
+	if r.URL != "" {
+		item += fmt.Sprintf(" ([%s](%s/commit/%s))", shortHash, r.URL, e.Hash)
+	} else {
+		item += fmt.Sprintf(" (%s)", shortHash)
+	}
+
+	issues := make([]string, 0)
+	for _, f := range e.Commit.Footers {
+		for _, token := range issueFooters {
+			if strings.EqualFold(f.Token, token) {
+				issues = append(issues, r.linkIssues(f.Value))
+			}
+		}
+	}
+	if len(issues) > 0 {
+		item += ", closes " + strings.Join(issues, ", ")
+	}
+
+	return "- " + item
+}
+
+func (r *Release) linkIssues(text string) string {
+	if r.URL == "" {
+		return text
+	}
+	return issueRegex.ReplaceAllString(text, fmt.Sprintf("$1[#$2](%s/issues/$2)", r.URL))
+}
+
+func writeGroup(sb *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("\n### %s\n\n", title))
+	for _, l := range lines {
+		sb.WriteString(l + "\n")
+	}
+}
+
+// Prepend inserts section above the latest release section of a changelog, creating the changelog when content is empty
+func Prepend(content []byte, section string) []byte {
+	if strings.TrimSpace(string(content)) == "" {
+		return []byte(fmt.Sprintf("%s\n\n%s", title, section))
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		if sectionHeadRegex.MatchString(line) {
+			before := strings.Join(lines[:i], "\n")
+			after := strings.Join(lines[i:], "\n")
+			return []byte(fmt.Sprintf("%s\n%s\n%s", before, section, after))
+		}
+	}
+
+	return []byte(fmt.Sprintf("%s\n\n%s", strings.TrimRight(string(content), "\n"), section))
+}
+
+// Check returns an error when content has no Unreleased section, or when it is empty and allowEmpty is not set
+func Check(content []byte, allowEmpty bool) error {
+	_, _, err := unreleased(strings.Split(string(content), "\n"), allowEmpty)
+	return err
+}
+
+// Promote renames the Unreleased section of a Keep-a-Changelog file to the release, inserts a fresh Unreleased section above it
+// and updates the compare links at the bottom of the file. The new content and the promoted section are returned.
+// An empty Unreleased section is refused unless allowEmpty is set.
+func Promote(content []byte, r *Release, allowEmpty bool) ([]byte, string, error) {
+	lines := strings.Split(string(content), "\n")
+
+	start, body, err := unreleased(lines, allowEmpty)
+	if err != nil {
+		return nil, "", err
+	}
+
+	header := fmt.Sprintf("## [%s] - %s", r.Version, r.Date.Format(DateFormat))
+	section := header + "\n"
+	if body != "" {
+		section += "\n" + body + "\n"
+	}
+
+	promoted := make([]string, 0, len(lines)+3)
+	promoted = append(promoted, lines[:start]...)
+	promoted = append(promoted, "## [Unreleased]", "", header)
+	for _, line := range lines[start+1:] {
+		m := unreleasedLinkRegex.FindStringSubmatch(line)
+		if m == nil {
+			promoted = append(promoted, line)
+			continue
+		}
+		label, url, previous := m[1], m[2], m[3]
+		promoted = append(promoted,
+			fmt.Sprintf("%s: %s/compare/%s...HEAD", label, url, r.Tag),
+			fmt.Sprintf("[%s]: %s/compare/%s...%s", r.Version, url, previous, r.Tag),
+		)
+	}
+
+	return []byte(strings.Join(promoted, "\n")), section, nil
+}
+
+// unreleased returns the line of the Unreleased section header and the trimmed content of the section,
+// which ends at the next section or at the link definitions
+func unreleased(lines []string, allowEmpty bool) (int, string, error) {
+	start := -1
+	for i, line := range lines {
+		if unreleasedHeadRegex.MatchString(line) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return 0, "", errors.New(ErrStrNoUnreleasedSection)
+	}
+
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if sectionHeadRegex.MatchString(lines[i]) || linkRegex.MatchString(lines[i]) {
+			end = i
+			break
+		}
+	}
+
+	body := strings.TrimSpace(strings.Join(lines[start+1:end], "\n"))
+	if body == "" && !allowEmpty {
+		return 0, "", errors.New(ErrStrEmptyUnreleasedSection)
+	}
+
+	return start, body, nil
+}
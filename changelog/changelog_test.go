@@ -0,0 +1,201 @@
+package changelog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nidhhoggr/version-bump/changelog"
+	"github.com/nidhhoggr/version-bump/conventional"
+	"github.com/stretchr/testify/assert"
+)
+
+func entry(hash string, message string) changelog.Entry {
+	c, _ := conventional.Parse(message)
+	return changelog.Entry{Hash: hash, Commit: c}
+}
+
+func TestChangelog_Section(t *testing.T) {
+	type test struct {
+		Release         *changelog.Release
+		ExpectedSection string
+	}
+
+	date := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	entries := []changelog.Entry{
+		entry("3f2a1bc9d0e1f2a3b4c5d6e7f8091a2b3c4d5e6f", "feat(api): add login (#12)"),
+		entry("a1b2c3d4e5f60718293a4b5c6d7e8f9012345678", "fix: crash on empty input\n\nCloses #7"),
+		entry("0123456789abcdef0123456789abcdef01234567", "refactor: rename the client\n\nBREAKING CHANGE: Client is now Session"),
+		entry("fedcba9876543210fedcba9876543210fedcba98", "chore: update dependencies"),
+	}
+
+	suite := map[string]test{
+		"Without URL": {
+			Release: &changelog.Release{Version: "2.0.0", Date: date, Tag: "v2.0.0", PreviousTag: "v1.2.0", Entries: entries},
+			ExpectedSection: `## [2.0.0] - 2026-10-18
+
+### BREAKING CHANGES
+
+- Client is now Session (0123456)
+
+### Features
+
+- **api:** add login (#12) (3f2a1bc)
+
+### Bug Fixes
+
+- crash on empty input (a1b2c3d), closes #7
+
+### Code Refactoring
+
+- rename the client (0123456)
+`,
+		},
+		"With URL": {
+			Release: &changelog.Release{Version: "1.3.0", Date: date, Tag: "v1.3.0", PreviousTag: "v1.2.0", URL: "https://github.com/acme/app", Entries: entries[:2]},
+			ExpectedSection: `## [1.3.0](https://github.com/acme/app/compare/v1.2.0...v1.3.0) - 2026-10-18
+
+### Features
+
+- **api:** add login ([#12](https://github.com/acme/app/issues/12)) ([3f2a1bc](https://github.com/acme/app/commit/3f2a1bc9d0e1f2a3b4c5d6e7f8091a2b3c4d5e6f))
+
+### Bug Fixes
+
+- crash on empty input ([a1b2c3d](https://github.com/acme/app/commit/a1b2c3d4e5f60718293a4b5c6d7e8f9012345678)), closes [#7](https://github.com/acme/app/issues/7)
+`,
+		},
+		"First Release": {
+			Release:         &changelog.Release{Version: "0.1.0", Date: date, Tag: "v0.1.0", URL: "https://github.com/acme/app", Entries: entries[3:]},
+			ExpectedSection: "## [0.1.0](https://github.com/acme/app/releases/tag/v0.1.0) - 2026-10-18\n",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a := assert.New(t)
+		a.Equal(test.ExpectedSection, changelog.Section(test.Release))
+	}
+}
+
+func TestChangelog_Prepend(t *testing.T) {
+	type test struct {
+		Content         string
+		ExpectedContent string
+	}
+
+	section := "## [1.3.0] - 2026-10-18\n\n### Features\n\n- add login (3f2a1bc)\n"
+
+	suite := map[string]test{
+		"New Changelog": {
+			Content:         "",
+			ExpectedContent: "# Changelog\n\n" + section,
+		},
+		"Existing Release": {
+			Content:         "# Changelog\n\nAll notable changes are documented here.\n\n## [1.2.0] - 2026-01-02\n\n- first release\n",
+			ExpectedContent: "# Changelog\n\nAll notable changes are documented here.\n\n" + section + "\n## [1.2.0] - 2026-01-02\n\n- first release\n",
+		},
+		"Title Only": {
+			Content:         "# Changelog\n",
+			ExpectedContent: "# Changelog\n\n" + section,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a := assert.New(t)
+		a.Equal(test.ExpectedContent, string(changelog.Prepend([]byte(test.Content), section)))
+	}
+}
+
+func TestChangelog_Promote(t *testing.T) {
+	type test struct {
+		Content          string
+		AllowEmpty       bool
+		ExpectedContent  string
+		ExpectedSection  string
+		ExpectedErrorStr string
+	}
+
+	release := &changelog.Release{Version: "1.3.0", Date: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Tag: "v1.3.0"}
+
+	suite := map[string]test{
+		"Promote With Links": {
+			Content: `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- login
+
+## [1.2.0] - 2026-01-02
+
+- first release
+
+[unreleased]: https://github.com/acme/app/compare/v1.2.0...HEAD
+[1.2.0]: https://github.com/acme/app/compare/v1.1.0...v1.2.0
+`,
+			ExpectedContent: `# Changelog
+
+## [Unreleased]
+
+## [1.3.0] - 2026-10-17
+
+### Added
+
+- login
+
+## [1.2.0] - 2026-01-02
+
+- first release
+
+[unreleased]: https://github.com/acme/app/compare/v1.3.0...HEAD
+[1.3.0]: https://github.com/acme/app/compare/v1.2.0...v1.3.0
+[1.2.0]: https://github.com/acme/app/compare/v1.1.0...v1.2.0
+`,
+			ExpectedSection: "## [1.3.0] - 2026-10-17\n\n### Added\n\n- login\n",
+		},
+		"Last Section Before Links": {
+			Content:         "## [Unreleased]\n- login\n[Unreleased]: https://example.com/compare/v1.2.0...HEAD\n",
+			ExpectedContent: "## [Unreleased]\n\n## [1.3.0] - 2026-10-17\n- login\n[Unreleased]: https://example.com/compare/v1.3.0...HEAD\n[1.3.0]: https://example.com/compare/v1.2.0...v1.3.0\n",
+			ExpectedSection: "## [1.3.0] - 2026-10-17\n\n- login\n",
+		},
+		"Empty Section Allowed": {
+			Content:         "## [Unreleased]\n\n## [1.2.0] - 2026-01-02\n",
+			AllowEmpty:      true,
+			ExpectedContent: "## [Unreleased]\n\n## [1.3.0] - 2026-10-17\n\n## [1.2.0] - 2026-01-02\n",
+			ExpectedSection: "## [1.3.0] - 2026-10-17\n",
+		},
+		"Empty Section": {
+			Content:          "## [Unreleased]\n\n## [1.2.0] - 2026-01-02\n",
+			ExpectedErrorStr: changelog.ErrStrEmptyUnreleasedSection,
+		},
+		"No Unreleased Section": {
+			Content:          "# Changelog\n\n## [1.2.0] - 2026-01-02\n",
+			ExpectedErrorStr: changelog.ErrStrNoUnreleasedSection,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		a := assert.New(t)
+		a.Equal(test.ExpectedErrorStr == "", changelog.Check([]byte(test.Content), test.AllowEmpty) == nil)
+
+		content, section, err := changelog.Promote([]byte(test.Content), release, test.AllowEmpty)
+		if test.ExpectedErrorStr != "" {
+			a.EqualError(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+		a.Equal(test.ExpectedContent, string(content))
+		a.Equal(test.ExpectedSection, section)
+	}
+}
@@ -20,6 +20,7 @@ var flags = &struct {
 	PrereleaseTypeAlpha      bool
 	PrereleaseTypeBeta       bool
 	PrereleaseTypeRc         bool
+	PrereleaseTypeBranch     bool
 	interactiveMode          bool
 	autoConfirm              bool
 	disablePrompts           bool
@@ -27,6 +28,18 @@ var flags = &struct {
 	shouldDebug              bool
 	PrereleaseMetadataString string
 	passphrase               string
+	goModule                 string
+	packageName              string
+	verifyAPI                bool
+	allowEmptyChangelog      bool
+	push                     bool
+	allowDirty               bool
+	allowAnyBranch           bool
+	allowBehind              bool
+	allowTagged              bool
+	tagOnly                  bool
+	describeFormat           string
+	dir                      string
 }{}
 
 var rootCmd = &cobra.Command{
@@ -47,25 +60,76 @@ as well as automate prerelease versioning and promotion.`,
 	Version: bump.Version,
 }
 
+var suggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest the version increment required by the go API changes since the latest release",
+	Long: `Compares the exported API of the go module at its latest release tag with the working tree
+and reports whether the changes require a major, minor or patch version increment.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runSuggestMode()
+	},
+}
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [major|minor|patch]",
+	Short: "Print a development version of HEAD following the latest release, e.g. 1.4.0-dev.7+g3f2a1bc",
+	Long: `Computes the version following the latest release tag reachable from HEAD, a patch by default,
+along with the number of commits since the tag and the abbreviated hash of HEAD.`,
+	ValidArgs: version.TypeStrings[:3],
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	Run: func(cmd *cobra.Command, args []string) {
+		runDescribeMode(args)
+	},
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Revert the latest bump which has not been pushed yet",
+	Long: `Deletes the release tag pointing at HEAD along with its alias tags, and moves the branch, the index
+and the working tree back to the parent of the release commit. Refuses when HEAD is not a release commit
+or when the remote of the [push] section already has the release tag.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runUndoMode()
+	},
+}
+
 func main() {
+	rootCmd.AddCommand(suggestCmd)
+	rootCmd.AddCommand(describeCmd)
+	rootCmd.AddCommand(undoCmd)
+	describeCmd.Flags().StringVar(&flags.describeFormat, "format", "", "Go template of the described version, by default "+bump.DefaultDescribeFormat)
+	rootCmd.PersistentFlags().StringVar(&flags.dir, "dir", currentDir, "directory of the project within the repository, whose .bump file is used instead of the one at the root of the repository")
 	rootCmd.PersistentFlags().BoolVar(&flags.PrereleaseTypeAlpha, "alpha", false, "alpha Prerelease")
 	rootCmd.PersistentFlags().BoolVar(&flags.PrereleaseTypeBeta, "beta", false, "beta Prerelease")
 	rootCmd.PersistentFlags().BoolVar(&flags.PrereleaseTypeRc, "rc", false, "release candidate Prerelease")
+	rootCmd.PersistentFlags().BoolVar(&flags.PrereleaseTypeBranch, "branch", false, "Prerelease named after the current branch")
 	rootCmd.PersistentFlags().BoolVar(&flags.interactiveMode, "interactive", false, "enable interactive mode")
 	rootCmd.PersistentFlags().BoolVar(&flags.autoConfirm, "auto-confirm", false, "disable confirmation prompts and automatically confirm")
 	rootCmd.PersistentFlags().BoolVar(&flags.disablePrompts, "disable-prompts", false, "disable passphrase and confirmation prompts. Caution: this will result in unsigned commits, tags and releases!")
 	rootCmd.PersistentFlags().BoolVar(&flags.isDryRun, "dry-run", false, "perform a dry run without modifying any files or interacting with git")
 	rootCmd.PersistentFlags().BoolVar(&flags.shouldDebug, "debug", false, "output debug information to the console")
 	rootCmd.PersistentFlags().StringVar(&flags.PrereleaseMetadataString, "metadata", "", "provide metadata for the Prerelease")
+	rootCmd.PersistentFlags().StringVar(&flags.packageName, "package", "", "release the package with this name from the [[package]] sections of the .bump file")
+	rootCmd.PersistentFlags().StringVar(&flags.goModule, "module", "", "release the go module in this directory, tagged with the directory as a prefix e.g. tools/cli/v1.4.0")
+	rootCmd.PersistentFlags().BoolVar(&flags.allowDirty, "allow-dirty", false, "release even when the working tree has uncommitted changes")
+	rootCmd.PersistentFlags().BoolVar(&flags.allowAnyBranch, "allow-any-branch", false, "release even from a branch which is not allowed by the [checks] section")
+	rootCmd.PersistentFlags().BoolVar(&flags.allowBehind, "allow-behind", false, "release even when HEAD is behind its upstream branch")
+	rootCmd.PersistentFlags().BoolVar(&flags.allowTagged, "allow-tagged", false, "release even when HEAD is already tagged with a version")
+	rootCmd.PersistentFlags().BoolVar(&flags.tagOnly, "tag-only", false, "read the version from the highest reachable tag and only tag HEAD, without changing any file")
+	rootCmd.PersistentFlags().BoolVar(&flags.push, "push", false, "push the current branch and the release tags to the remote of the [push] section, origin by default")
+	rootCmd.PersistentFlags().BoolVar(&flags.allowEmptyChangelog, "allow-empty-changelog", false, "release even when the Unreleased section of the changelog is empty")
+	rootCmd.PersistentFlags().BoolVar(&flags.verifyAPI, "verify-api", false, "refuse version increments smaller than the one required by the go API changes since the latest release")
 	rootCmd.PersistentFlags().StringVar(&flags.passphrase, "passphrase", "", "provide gpg passphrase as a flag instead of a secure prompt. Caution!")
 	cobra.CheckErr(rootCmd.Execute())
 }
 
 func runPromptMode(cmd *cobra.Command, args []string) {
-	hasPrerelease := flags.PrereleaseTypeAlpha || flags.PrereleaseTypeBeta || flags.PrereleaseTypeRc
+	hasPrerelease := flags.PrereleaseTypeAlpha || flags.PrereleaseTypeBeta || flags.PrereleaseTypeRc || flags.PrereleaseTypeBranch
 	if len(args) == 1 || hasPrerelease {
 		console.DebuggingEnabled = flags.shouldDebug
-		b, err := bump.New(currentDir)
+		b, err := bump.New(flags.dir)
 		if err != nil {
 			console.Fatal(err)
 		}
@@ -84,16 +148,28 @@ func runPromptMode(cmd *cobra.Command, args []string) {
 				PrereleaseType = version.BetaPrerelease
 			} else if flags.PrereleaseTypeRc {
 				PrereleaseType = version.ReleaseCandidate
+			} else if flags.PrereleaseTypeBranch {
+				PrereleaseType = version.BranchPrerelease
 			}
 		}
 
 		err = b.Run(&bump.RunArgs{
-			ConfirmationPrompt: confirmationPrompt,
-			PassphrasePrompt:   passphrasePrompt,
-			VersionType:        versionType,
-			PrereleaseType:     PrereleaseType,
-			PrereleaseMetadata: flags.PrereleaseMetadataString,
-			IsDryRun:           flags.isDryRun,
+			ConfirmationPrompt:  confirmationPrompt,
+			PassphrasePrompt:    passphrasePrompt,
+			VersionType:         versionType,
+			PrereleaseType:      PrereleaseType,
+			PrereleaseMetadata:  flags.PrereleaseMetadataString,
+			Package:             flags.packageName,
+			GoModule:            flags.goModule,
+			IsDryRun:            flags.isDryRun,
+			VerifyAPI:           flags.verifyAPI,
+			AllowEmptyChangelog: flags.allowEmptyChangelog,
+			Push:                flags.push,
+			AllowDirty:          flags.allowDirty,
+			AllowAnyBranch:      flags.allowAnyBranch,
+			AllowBehind:         flags.allowBehind,
+			AllowTagged:         flags.allowTagged,
+			TagOnly:             flags.tagOnly,
 		})
 		if err != nil {
 			console.Fatal(err)
@@ -138,17 +214,81 @@ func runInteractiveMode() {
 	}
 
 	console.DebuggingEnabled = flags.shouldDebug
-	b, err := bump.New(currentDir)
+	b, err := bump.New(flags.dir)
 	if err != nil {
 		console.Fatal(err)
 	}
 	err = b.Run(&bump.RunArgs{
-		ConfirmationPrompt: confirmationPrompt,
-		PassphrasePrompt:   passphrasePrompt,
-		VersionType:        versionType,
-		PrereleaseType:     PrereleaseType,
-		PrereleaseMetadata: PrereleaseMetadata,
-		IsDryRun:           flags.isDryRun,
+		ConfirmationPrompt:  confirmationPrompt,
+		PassphrasePrompt:    passphrasePrompt,
+		VersionType:         versionType,
+		PrereleaseType:      PrereleaseType,
+		PrereleaseMetadata:  PrereleaseMetadata,
+		Package:             flags.packageName,
+		GoModule:            flags.goModule,
+		IsDryRun:            flags.isDryRun,
+		VerifyAPI:           flags.verifyAPI,
+		AllowEmptyChangelog: flags.allowEmptyChangelog,
+		Push:                flags.push,
+		AllowDirty:          flags.allowDirty,
+		AllowAnyBranch:      flags.allowAnyBranch,
+		AllowBehind:         flags.allowBehind,
+		AllowTagged:         flags.allowTagged,
+		TagOnly:             flags.tagOnly,
+	})
+	if err != nil {
+		console.Fatal(err)
+	}
+}
+
+func runSuggestMode() {
+	console.DebuggingEnabled = flags.shouldDebug
+	b, err := bump.New(flags.dir)
+	if err != nil {
+		console.Fatal(err)
+	}
+	_, err = b.Suggest(&bump.RunArgs{
+		Package:  flags.packageName,
+		GoModule: flags.goModule,
+	})
+	if err != nil {
+		console.Fatal(err)
+	}
+}
+
+func runDescribeMode(args []string) {
+	console.DebuggingEnabled = flags.shouldDebug
+	b, err := bump.New(flags.dir)
+	if err != nil {
+		console.Fatal(err)
+	}
+	versionType := version.NotAVersion
+	if len(args) == 1 {
+		versionType = version.FromString(args[0])
+	}
+	described, err := b.Describe(&bump.RunArgs{
+		Package:        flags.packageName,
+		GoModule:       flags.goModule,
+		VersionType:    versionType,
+		DescribeFormat: flags.describeFormat,
+	})
+	if err != nil {
+		console.Fatal(err)
+	}
+	console.Description(described)
+}
+
+func runUndoMode() {
+	console.DebuggingEnabled = flags.shouldDebug
+	b, err := bump.New(flags.dir)
+	if err != nil {
+		console.Fatal(err)
+	}
+	err = b.Undo(&bump.RunArgs{
+		PassphrasePrompt: passphrasePrompt,
+		Package:          flags.packageName,
+		GoModule:         flags.goModule,
+		IsDryRun:         flags.isDryRun,
 	})
 	if err != nil {
 		console.Fatal(err)
@@ -33,6 +33,43 @@ func CommittingChanges() {
 	fmt.Println("Committing changes...")
 }
 
+func PushingChanges(remote string) {
+	fmt.Printf("Pushing changes to %v%v%v...\n", colorCyan, remote, colorReset)
+}
+
+func TaggingHead(oldVersion, newVersion, tag string, isDryRun bool) {
+	action := "Tagging"
+	if isDryRun {
+		action = "Will tag"
+	}
+	fmt.Printf("\n  %s HEAD:\n", action)
+	fmt.Print(VersionUpdate(oldVersion, newVersion, tag))
+}
+
+func UndoingRelease(tags []string, aliases []string, commit string, isDryRun bool) {
+	action := "Deleting"
+	if isDryRun {
+		action = "Will delete"
+	}
+	noun := "tag"
+	if len(tags) > 1 {
+		noun = "tags"
+	}
+	fmt.Printf("%s %s %v%v%v", action, noun, colorCyan, strings.Join(tags, ", "), colorReset)
+	if len(aliases) > 0 {
+		fmt.Printf(" and alias tags %v%v%v", colorCyan, strings.Join(aliases, ", "), colorReset)
+	}
+	fmt.Printf(", and resetting to the parent of %v%v%v...\n", colorYellow, commit, colorReset)
+}
+
+func CreatingBranch(name string, isDryRun bool) {
+	action := "Creating"
+	if isDryRun {
+		action = "Will create"
+	}
+	fmt.Printf("%s release branch %v%v%v...\n", action, colorCyan, name, colorReset)
+}
+
 func Language(name string, isDryRun bool) {
 	action := "Updating"
 	if isDryRun {
@@ -72,6 +109,61 @@ func VersionUpdateField(oldVersion, newVersion, filepath string, field string) {
 	)
 }
 
+func ModulePathUpdate(oldPath, newPath, filepath string) {
+	fmt.Printf("    %v%v%v -> %v%v%v %v\n",
+		colorYellow, oldPath, colorReset,
+		colorGreen, newPath, colorReset,
+		filepath,
+	)
+}
+
+func DependencyUpdate(dependency, newVersion, filepath string) {
+	fmt.Printf("    %v%v%v -> %v%v%v %v\n",
+		colorYellow, dependency, colorReset,
+		colorGreen, newVersion, colorReset,
+		filepath,
+	)
+}
+
+func ChangelogSection(newVersion, filepath string) {
+	fmt.Printf("    + %v[%v]%v %v\n",
+		colorGreen, newVersion, colorReset,
+		filepath,
+	)
+}
+
+func APIChanges(since string, incompatible []string, compatible []string) {
+	fmt.Printf("\n  API changes since %v%v%v:\n", colorCyan, since, colorReset)
+	for _, change := range incompatible {
+		fmt.Printf("    %v- %v%v\n", colorRed, change, colorReset)
+	}
+	for _, change := range compatible {
+		fmt.Printf("    %v+ %v%v\n", colorGreen, change, colorReset)
+	}
+	if len(incompatible) == 0 && len(compatible) == 0 {
+		fmt.Println("    none")
+	}
+}
+
+func SuggestedVersionType(versionType string) {
+	fmt.Printf("\n  Suggested version increment: %v%v%v\n", colorGreen, versionType, colorReset)
+}
+
+func ConventionalCommits(since string, commits []string) {
+	fmt.Printf("\n  Commits since %v%v%v:\n", colorCyan, since, colorReset)
+	for _, commit := range commits {
+		fmt.Printf("    %v\n", commit)
+	}
+}
+
+func InferredVersionType(versionType string) {
+	fmt.Printf("\n  Inferred version increment: %v%v%v\n", colorGreen, versionType, colorReset)
+}
+
+func Description(version string) {
+	fmt.Println(version)
+}
+
 func UpdateAvailable(version string, repoName string) {
 	fmt.Printf("%vThe new version is available! Download from https://github.com/%s/releases/tag/%v%v\n",
 		colorGreen, repoName, version, colorReset,
@@ -0,0 +1,133 @@
+package conventional
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/nidhhoggr/version-bump/version"
+)
+
+const (
+	// Feature is the type of commits adding functionality
+	Feature = "feat"
+	// Fix is the type of commits fixing a bug
+	Fix = "fix"
+	// BreakingChange is the footer token describing an incompatible change
+	BreakingChange = "BREAKING CHANGE"
+)
+
+var (
+	headerRegex = regexp.MustCompile(`^(?P<type>[A-Za-z]+)(?:\((?P<scope>[^()\r\n]*)\))?(?P<breaking>!)?: (?P<description>\S.*)$`)
+	footerRegex = regexp.MustCompile(`^(?P<token>BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z-]*)(?:: | #)(?P<value>.*)$`)
+)
+
+// Footer is a git trailer like footer of a commit message, e.g. Refs: #123
+type Footer struct {
+	Token string
+	Value string
+}
+
+// Commit is a commit message following the Conventional Commits specification
+type Commit struct {
+	// Header is the first line of the commit message
+	Header      string
+	Type        string
+	Scope       string
+	Description string
+	Body        string
+	Footers     []Footer
+	Breaking    bool
+}
+
+// Parse returns the conventional commit of message, or false when its header does not follow the specification
+func Parse(message string) (*Commit, bool) {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	header, rest, _ := strings.Cut(strings.TrimSpace(message), "\n")
+
+	m := headerRegex.FindStringSubmatch(strings.TrimSpace(header))
+	if m == nil {
+		return nil, false
+	}
+
+	c := &Commit{
+		Header:      strings.TrimSpace(header),
+		Type:        strings.ToLower(m[headerRegex.SubexpIndex("type")]),
+		Scope:       m[headerRegex.SubexpIndex("scope")],
+		Description: strings.TrimSpace(m[headerRegex.SubexpIndex("description")]),
+		Breaking:    m[headerRegex.SubexpIndex("breaking")] == "!",
+		Footers:     make([]Footer, 0),
+	}
+
+	paragraphs := strings.Split(strings.TrimSpace(rest), "\n\n")
+	body := paragraphs
+	if last := paragraphs[len(paragraphs)-1]; isFooters(last) {
+		body = paragraphs[:len(paragraphs)-1]
+		c.Footers = parseFooters(last)
+	}
+	c.Body = strings.TrimSpace(strings.Join(body, "\n\n"))
+
+	for _, f := range c.Footers {
+		if f.Token == BreakingChange {
+			c.Breaking = true
+		}
+	}
+
+	return c, true
+}
+
+// VersionType returns the version increment required by the commit.
+// Breaking changes of versions in initial development (0.x) only require a minor increment.
+func (c *Commit) VersionType(initialDevelopment bool) version.Type {
+	if c.Breaking {
+		if initialDevelopment {
+			return version.Minor
+		}
+		return version.Major
+	}
+	switch c.Type {
+	case Feature:
+		return version.Minor
+	case Fix:
+		return version.Patch
+	}
+	return version.NotAVersion
+}
+
+// VersionType returns the largest version increment required by commits, or NotAVersion when none requires a release
+func VersionType(commits []*Commit, initialDevelopment bool) version.Type {
+	versionType := version.NotAVersion
+	for _, c := range commits {
+		t := c.VersionType(initialDevelopment)
+		// version types are ordered from major to patch
+		if t != version.NotAVersion && (versionType == version.NotAVersion || t < versionType) {
+			versionType = t
+		}
+	}
+	return versionType
+}
+
+func isFooters(paragraph string) bool {
+	lines := strings.Split(paragraph, "\n")
+	return paragraph != "" && footerRegex.MatchString(lines[0])
+}
+
+// parseFooters splits a paragraph of footers, where values may continue on the following lines
+func parseFooters(paragraph string) []Footer {
+	footers := make([]Footer, 0)
+	for _, line := range strings.Split(paragraph, "\n") {
+		if m := footerRegex.FindStringSubmatch(line); m != nil {
+			token := m[footerRegex.SubexpIndex("token")]
+			value := m[footerRegex.SubexpIndex("value")]
+			if strings.HasPrefix(line[len(token):], " #") {
+				value = "#" + value
+			}
+			if token == "BREAKING-CHANGE" {
+				token = BreakingChange
+			}
+			footers = append(footers, Footer{Token: token, Value: value})
+		} else if len(footers) > 0 {
+			footers[len(footers)-1].Value += "\n" + line
+		}
+	}
+	return footers
+}
@@ -0,0 +1,121 @@
+package conventional_test
+
+import (
+	"testing"
+
+	"github.com/nidhhoggr/version-bump/conventional"
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConventional_Parse(t *testing.T) {
+	type test struct {
+		Message        string
+		ExpectedOk     bool
+		ExpectedCommit *conventional.Commit
+	}
+
+	suite := map[string]test{
+		"Feature": {
+			Message:    "feat: add login\n",
+			ExpectedOk: true,
+			ExpectedCommit: &conventional.Commit{
+				Header:      "feat: add login",
+				Type:        "feat",
+				Description: "add login",
+				Footers:     []conventional.Footer{},
+			},
+		},
+		"Scoped Breaking Fix": {
+			Message:    "fix(api)!: drop the v1 endpoints",
+			ExpectedOk: true,
+			ExpectedCommit: &conventional.Commit{
+				Header:      "fix(api)!: drop the v1 endpoints",
+				Type:        "fix",
+				Scope:       "api",
+				Description: "drop the v1 endpoints",
+				Breaking:    true,
+				Footers:     []conventional.Footer{},
+			},
+		},
+		"Body And Footers": {
+			Message: `refactor: rename the client
+
+The client is now called Session.
+
+It keeps the same methods.
+
+BREAKING CHANGE: Client was renamed to Session
+and NewClient to NewSession
+Refs: #123
+Closes #7
+`,
+			ExpectedOk: true,
+			ExpectedCommit: &conventional.Commit{
+				Header:      "refactor: rename the client",
+				Type:        "refactor",
+				Description: "rename the client",
+				Body:        "The client is now called Session.\n\nIt keeps the same methods.",
+				Breaking:    true,
+				Footers: []conventional.Footer{
+					{Token: "BREAKING CHANGE", Value: "Client was renamed to Session\nand NewClient to NewSession"},
+					{Token: "Refs", Value: "#123"},
+					{Token: "Closes", Value: "#7"},
+				},
+			},
+		},
+		"Hyphenated Breaking Change Footer": {
+			Message:    "chore: update\n\nBREAKING-CHANGE: requires go 1.23",
+			ExpectedOk: true,
+			ExpectedCommit: &conventional.Commit{
+				Header:      "chore: update",
+				Type:        "chore",
+				Description: "update",
+				Breaking:    true,
+				Footers: []conventional.Footer{
+					{Token: "BREAKING CHANGE", Value: "requires go 1.23"},
+				},
+			},
+		},
+		"Not Conventional": {
+			Message:    "Merge branch 'main' into feature",
+			ExpectedOk: false,
+		},
+		"Missing Description": {
+			Message:    "feat: ",
+			ExpectedOk: false,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		c, ok := conventional.Parse(test.Message)
+		a := assert.New(t)
+		a.Equal(test.ExpectedOk, ok)
+		a.Equal(test.ExpectedCommit, c)
+	}
+}
+
+func TestConventional_VersionType(t *testing.T) {
+	a := assert.New(t)
+
+	parse := func(messages ...string) []*conventional.Commit {
+		commits := make([]*conventional.Commit, 0)
+		for _, m := range messages {
+			c, ok := conventional.Parse(m)
+			a.True(ok)
+			commits = append(commits, c)
+		}
+		return commits
+	}
+
+	a.Equal(version.NotAVersion, conventional.VersionType(parse("docs: typo", "chore: deps"), false))
+	a.Equal(version.Patch, conventional.VersionType(parse("docs: typo", "fix: crash"), false))
+	a.Equal(version.Minor, conventional.VersionType(parse("fix: crash", "feat: login", "fix: typo"), false))
+	a.Equal(version.Major, conventional.VersionType(parse("fix: crash", "feat!: new api", "feat: login"), false))
+	a.Equal(version.Minor, conventional.VersionType(parse("fix: crash", "feat!: new api"), true))
+	a.Equal(version.Patch, conventional.VersionType(parse("fix: crash"), true))
+}
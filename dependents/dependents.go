@@ -0,0 +1,161 @@
+package dependents
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nidhhoggr/version-bump/version"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	// PackageJSON is the manifest of a JavaScript package
+	PackageJSON = "package.json"
+	// CargoToml is the manifest of a Rust crate
+	CargoToml = "Cargo.toml"
+)
+
+// NpmDependencyFields are the fields of a package.json declaring dependencies
+var NpmDependencyFields = []string{
+	"dependencies",
+	"devDependencies",
+	"peerDependencies",
+	"optionalDependencies",
+}
+
+// CargoDependencyTables are the tables of a Cargo.toml declaring dependencies
+var CargoDependencyTables = []string{
+	"dependencies",
+	"dev-dependencies",
+	"build-dependencies",
+}
+
+var (
+	constraintRegex       = regexp.MustCompile(fmt.Sprintf(`^((?:workspace:)?(?:\^|~>?|>=|<=|>|<|=)?\s*)(%v)$`, version.Regex))
+	tomlTableRegex        = regexp.MustCompile(`^\s*\[\s*([^\[\]]+?)\s*\]\s*(?:#.*)?$`)
+	tomlKeyRegex          = regexp.MustCompile(`^\s*([A-Za-z0-9_\-]+|"[^"]+")\s*=`)
+	tomlVersionValueRegex = regexp.MustCompile(`((?:^|[{,\s])version\s*=\s*")([^"]*)(")`)
+	tomlStringValueRegex  = regexp.MustCompile(`^(\s*[A-Za-z0-9_\-"]+\s*=\s*")([^"]*)(")`)
+	tomlPackageNameRegex  = regexp.MustCompile(`^\s*name\s*=\s*"([^"]+)"`)
+)
+
+// ReplaceConstraintVersion replaces the version of a dependency constraint while keeping its range operator,
+// e.g. ^1.2.3 becomes ^1.3.0. It reports false for constraints which are not a single version, such as * or ranges.
+func ReplaceConstraintVersion(constraint string, newVersion string) (string, bool) {
+	m := constraintRegex.FindStringSubmatch(constraint)
+	if m == nil {
+		return constraint, false
+	}
+	return m[1] + newVersion, true
+}
+
+// NpmName returns the name of the package declared by a package.json
+func NpmName(packageJSON []byte) string {
+	return gjson.GetBytes(packageJSON, "name").String()
+}
+
+// SetNpmDependency updates the constraints on the named package in every dependency field of a package.json.
+// It reports whether any constraint changed.
+func SetNpmDependency(packageJSON []byte, name string, newVersion string) ([]byte, bool, error) {
+	changed := false
+	for _, field := range NpmDependencyFields {
+		fieldPath := fmt.Sprintf("%s.%s", field, escapeJSONPath(name))
+		constraint := gjson.GetBytes(packageJSON, fieldPath)
+		if !constraint.Exists() {
+			continue
+		}
+		newConstraint, ok := ReplaceConstraintVersion(constraint.String(), newVersion)
+		if !ok || newConstraint == constraint.String() {
+			continue
+		}
+		var err error
+		packageJSON, err = sjson.SetBytes(packageJSON, fieldPath, newConstraint)
+		if err != nil {
+			return nil, false, err
+		}
+		changed = true
+	}
+	return packageJSON, changed, nil
+}
+
+// CrateName returns the name of the crate declared by the [package] table of a Cargo.toml
+func CrateName(cargoToml []byte) string {
+	table := ""
+	for _, line := range strings.Split(string(cargoToml), "\n") {
+		if m := tomlTableRegex.FindStringSubmatch(line); m != nil {
+			table = m[1]
+			continue
+		}
+		if table != "package" {
+			continue
+		}
+		if m := tomlPackageNameRegex.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// SetCrateDependency updates the version requirement on the named crate in every dependency table of a Cargo.toml.
+// Both `name = { path = "..", version = "1.2.3" }` and `[dependencies.name]` forms are supported. It reports whether any requirement changed.
+func SetCrateDependency(cargoToml []byte, name string, newVersion string) ([]byte, bool) {
+	lines := strings.Split(string(cargoToml), "\n")
+	changed := false
+	table := ""
+
+	for i, line := range lines {
+		if m := tomlTableRegex.FindStringSubmatch(line); m != nil {
+			table = m[1]
+			continue
+		}
+
+		var re *regexp.Regexp
+		if isCargoDependencyTable(table) {
+			m := tomlKeyRegex.FindStringSubmatch(line)
+			if m == nil || strings.Trim(m[1], `"`) != name {
+				continue
+			}
+			// either an inline table or a plain version string
+			re = tomlVersionValueRegex
+			if !strings.Contains(line, "{") {
+				re = tomlStringValueRegex
+			}
+		} else if isCargoDependencyTable(strings.TrimSuffix(table, "."+name)) && strings.HasSuffix(table, "."+name) {
+			if m := tomlKeyRegex.FindStringSubmatch(line); m == nil || m[1] != "version" {
+				continue
+			}
+			re = tomlStringValueRegex
+		} else {
+			continue
+		}
+
+		m := re.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		newConstraint, ok := ReplaceConstraintVersion(line[m[4]:m[5]], newVersion)
+		if !ok || newConstraint == line[m[4]:m[5]] {
+			continue
+		}
+		lines[i] = line[:m[4]] + newConstraint + line[m[5]:]
+		changed = true
+	}
+
+	return []byte(strings.Join(lines, "\n")), changed
+}
+
+func isCargoDependencyTable(table string) bool {
+	for _, t := range CargoDependencyTables {
+		if table == t || strings.HasSuffix(table, "."+t) {
+			return true
+		}
+	}
+	return false
+}
+
+func escapeJSONPath(path string) string {
+	replacer := strings.NewReplacer(".", `\.`, "*", `\*`, "?", `\?`)
+	return replacer.Replace(path)
+}
@@ -0,0 +1,133 @@
+package dependents_test
+
+import (
+	"testing"
+
+	"github.com/nidhhoggr/version-bump/dependents"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDependents_ReplaceConstraintVersion(t *testing.T) {
+	type test struct {
+		Constraint         string
+		ExpectedConstraint string
+		ExpectedOk         bool
+	}
+
+	suite := map[string]test{
+		"Exact":              {Constraint: "1.2.3", ExpectedConstraint: "1.3.0", ExpectedOk: true},
+		"Caret":              {Constraint: "^1.2.3", ExpectedConstraint: "^1.3.0", ExpectedOk: true},
+		"Tilde":              {Constraint: "~1.2.3", ExpectedConstraint: "~1.3.0", ExpectedOk: true},
+		"Greater Or Equal":   {Constraint: ">= 1.2.3", ExpectedConstraint: ">= 1.3.0", ExpectedOk: true},
+		"Workspace Caret":    {Constraint: "workspace:^1.2.3", ExpectedConstraint: "workspace:^1.3.0", ExpectedOk: true},
+		"Prerelease":         {Constraint: "^1.2.3-rc.1", ExpectedConstraint: "^1.3.0", ExpectedOk: true},
+		"Any":                {Constraint: "*", ExpectedConstraint: "*", ExpectedOk: false},
+		"Workspace Any":      {Constraint: "workspace:*", ExpectedConstraint: "workspace:*", ExpectedOk: false},
+		"Range":              {Constraint: ">=1.2.3 <2.0.0", ExpectedConstraint: ">=1.2.3 <2.0.0", ExpectedOk: false},
+		"Partial Version":    {Constraint: "^1.2", ExpectedConstraint: "^1.2", ExpectedOk: false},
+		"File Path":          {Constraint: "file:../api", ExpectedConstraint: "file:../api", ExpectedOk: false},
+		"Trailing Qualifier": {Constraint: "1.2.3 || 2.0.0", ExpectedConstraint: "1.2.3 || 2.0.0", ExpectedOk: false},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		constraint, ok := dependents.ReplaceConstraintVersion(test.Constraint, "1.3.0")
+		a := assert.New(t)
+		a.Equal(test.ExpectedConstraint, constraint)
+		a.Equal(test.ExpectedOk, ok)
+	}
+}
+
+func TestDependents_Npm(t *testing.T) {
+	a := assert.New(t)
+
+	packageJSON := `{
+  "name": "@acme/web",
+  "version": "4.0.0",
+  "dependencies": {
+    "@acme/api.client": "^1.2.3",
+    "left-pad": "^1.2.3"
+  },
+  "devDependencies": {
+    "@acme/api.client": "workspace:~1.2.3"
+  },
+  "peerDependencies": {
+    "@acme/api.client": "*"
+  }
+}
+`
+	a.Equal("@acme/web", dependents.NpmName([]byte(packageJSON)))
+
+	res, changed, err := dependents.SetNpmDependency([]byte(packageJSON), "@acme/api.client", "1.3.0")
+	a.Nil(err)
+	a.True(changed)
+	a.Equal(`{
+  "name": "@acme/web",
+  "version": "4.0.0",
+  "dependencies": {
+    "@acme/api.client": "^1.3.0",
+    "left-pad": "^1.2.3"
+  },
+  "devDependencies": {
+    "@acme/api.client": "workspace:~1.3.0"
+  },
+  "peerDependencies": {
+    "@acme/api.client": "*"
+  }
+}
+`, string(res))
+
+	res, changed, err = dependents.SetNpmDependency([]byte(packageJSON), "@acme/other", "1.3.0")
+	a.Nil(err)
+	a.False(changed)
+	a.Equal(packageJSON, string(res))
+}
+
+func TestDependents_Cargo(t *testing.T) {
+	a := assert.New(t)
+
+	cargoToml := `[package]
+name = "web"
+version = "4.0.0"
+
+[dependencies]
+api = { path = "../api", version = "^1.2.3" }
+api-macros = { path = "../api-macros", version = "1.2.3" }
+serde = "1.2.3"
+
+[dev-dependencies.api]
+path = "../api"
+version = "~1.2.3"
+
+[target.'cfg(unix)'.build-dependencies]
+"api" = { version = "=1.2.3", path = "../api" }
+`
+	a.Equal("web", dependents.CrateName([]byte(cargoToml)))
+	a.Equal("", dependents.CrateName([]byte("[workspace]\nname = \"web\"\n")))
+
+	res, changed := dependents.SetCrateDependency([]byte(cargoToml), "api", "1.3.0")
+	a.True(changed)
+	a.Equal(`[package]
+name = "web"
+version = "4.0.0"
+
+[dependencies]
+api = { path = "../api", version = "^1.3.0" }
+api-macros = { path = "../api-macros", version = "1.2.3" }
+serde = "1.2.3"
+
+[dev-dependencies.api]
+path = "../api"
+version = "~1.3.0"
+
+[target.'cfg(unix)'.build-dependencies]
+"api" = { version = "=1.3.0", path = "../api" }
+`, string(res))
+
+	res, changed = dependents.SetCrateDependency([]byte(cargoToml), "web", "1.3.0")
+	a.False(changed)
+	a.Equal(cargoToml, string(res))
+}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrStoringReferences = "the repository has no reference storer"
+
+	ErrStrFormattedCreatingBranch      = "creating branch %v"
+	ErrStrFormattedBranchAlreadyExists = "branch %v already exists at %v"
+)
+
+const (
+	// DefaultReleaseBranchTemplate is the name of release branches when no ReleaseBranch is set
+	DefaultReleaseBranchTemplate = "release/{{major}}.{{minor}}"
+	// DefaultPrereleaseIdentifierTemplate names branch prereleases when no PrereleaseIdentifier is set
+	DefaultPrereleaseIdentifierTemplate = "{{.Branch}}"
+
+	// PrereleaseCounterTags counts branch prereleases past the counters of their tags, the default
+	PrereleaseCounterTags = "tags"
+	// PrereleaseCounterCommits counts branch prereleases with the number of commits reachable from HEAD
+	PrereleaseCounterCommits = "commits"
+)
+
+// BranchesConfig used to parse the [branches] section of the .bump toml file
+type BranchesConfig struct {
+	// Main are the patterns of the branches whose X.Y.0 releases create a release branch, e.g. main
+	Main []string
+	// CreateReleaseBranch creates the release branch of the X.Y.0 releases of the main branches
+	CreateReleaseBranch bool `toml:"create_release_branch"`
+	// ReleaseBranch is the Go template of the name of release branches, default release/{{major}}.{{minor}}
+	ReleaseBranch string `toml:"release_branch"`
+	// Maintenance are the patterns of the branches which only allow patch versions and prereleases, e.g. release/*
+	Maintenance []string
+	// Prereleases maps patterns of branches to the prerelease type of their releases, e.g. develop = "beta"
+	Prereleases map[string]string
+	// PrereleaseIdentifier is the Go template of the identifier of branch prereleases, default {{.Branch}}. The branch is sanitized to
+	// follow the semver rules, e.g. feat-login for the branch feat/login
+	PrereleaseIdentifier string `toml:"prerelease_identifier"`
+	// PrereleaseCounter is the counter of branch prereleases, either tags or commits
+	PrereleaseCounter string `toml:"prerelease_counter"`
+}
+
+// ReleaseBranchName returns the name of the release branch of version
+func (cfg *BranchesConfig) ReleaseBranchName(version string) (string, error) {
+	text := cfg.ReleaseBranch
+	if text == "" {
+		text = DefaultReleaseBranchTemplate
+	}
+	return Render(text, &Release{Version: version})
+}
+
+// RenderPrereleaseIdentifier renders the identifier of the branch prereleases released from branch
+func (cfg *BranchesConfig) RenderPrereleaseIdentifier(branch string) (string, error) {
+	text := cfg.PrereleaseIdentifier
+	if text == "" {
+		text = DefaultPrereleaseIdentifierTemplate
+	}
+	return Render(text, &Release{Branch: branch})
+}
+
+// CreateBranch creates the branch name on hash, refusing to move an existing branch elsewhere
+func (i *Instance) CreateBranch(name string, hash plumbing.Hash) error {
+	refName := plumbing.NewBranchReferenceName(name)
+	if err := refName.Validate(); err != nil {
+		return errors.Wrapf(err, ErrStrFormattedCreatingBranch, name)
+	}
+
+	existing, err := i.Repository.Reference(refName, true)
+	if err == nil {
+		if existing.Hash() == hash {
+			return nil
+		}
+		return fmt.Errorf(ErrStrFormattedBranchAlreadyExists, name, existing.Hash().String()[:ShortHashLength])
+	} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return errors.Wrapf(err, ErrStrFormattedCreatingBranch, name)
+	}
+
+	if i.References == nil {
+		return errors.Wrapf(errors.New(ErrStrStoringReferences), ErrStrFormattedCreatingBranch, name)
+	}
+	if err := i.References.SetReference(plumbing.NewHashReference(refName, hash)); err != nil {
+		return errors.Wrapf(err, ErrStrFormattedCreatingBranch, name)
+	}
+	return nil
+}
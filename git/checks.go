@@ -0,0 +1,128 @@
+package git
+
+import (
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrReadingStatus   = "reading the working tree status"
+	ErrStrReadingUpstream = "reading the upstream of the current branch"
+)
+
+// ChecksConfig used to parse the [checks] section of the .bump toml file
+type ChecksConfig struct {
+	// Clean refuses to release when the working tree has changes, ignored files excepted
+	Clean bool
+	// Branches are the patterns of the branches releases are allowed from, e.g. main and release/*
+	Branches []string
+	// Upstream refuses to release when HEAD is behind the upstream tracking branch
+	Upstream bool
+	// Untagged refuses to release when HEAD is already tagged with a version
+	Untagged bool
+}
+
+// Changes returns the modified, staged and untracked files of the working tree. Ignored files are left out.
+func (i *Instance) Changes() ([]string, error) {
+	status, err := i.Worktree.Status()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingStatus)
+	}
+
+	files := make([]string, 0)
+	for file, s := range status {
+		if s.Staging != git.Unmodified || s.Worktree != git.Unmodified {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// Branch returns the short name of the checked out branch
+func (i *Instance) Branch() (string, error) {
+	head, err := i.Repository.Head()
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrReadingHead)
+	} else if !head.Name().IsBranch() {
+		return "", errors.New(ErrStrDetachedHead)
+	}
+	return head.Name().Short(), nil
+}
+
+// BehindUpstream returns the upstream tracking branch of the current branch when it has commits which HEAD does not contain.
+// An empty name is returned when HEAD is up to date, or when the branch has no upstream.
+func (i *Instance) BehindUpstream() (string, error) {
+	head, err := i.Repository.Head()
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrReadingHead)
+	} else if !head.Name().IsBranch() {
+		return "", errors.New(ErrStrDetachedHead)
+	}
+
+	cfg, err := i.Repository.ConfigScoped(config.LocalScope)
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrReadingUpstream)
+	}
+	branch, ok := cfg.Branches[head.Name().Short()]
+	if !ok || branch.Remote == "" || branch.Merge == "" {
+		return "", nil
+	}
+
+	upstreamName := plumbing.NewRemoteReferenceName(branch.Remote, branch.Merge.Short())
+	if branch.Remote == "." {
+		upstreamName = branch.Merge
+	}
+	upstream, err := i.Repository.Reference(upstreamName, true)
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Wrap(err, ErrStrReadingUpstream)
+	}
+
+	if upstream.Hash() == head.Hash() {
+		return "", nil
+	}
+
+	headCommit, err := i.Repository.CommitObject(head.Hash())
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrReadingHead)
+	}
+	upstreamCommit, err := i.Repository.CommitObject(upstream.Hash())
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrReadingUpstream)
+	}
+	contained, err := upstreamCommit.IsAncestor(headCommit)
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrWalkingCommits)
+	} else if contained {
+		return "", nil
+	}
+
+	return upstreamName.Short(), nil
+}
+
+// HeadReleaseTag returns a release tag pointing to HEAD, or nil
+func (i *Instance) HeadReleaseTag() (*ReleaseTag, error) {
+	head, err := i.Repository.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingHead)
+	}
+
+	tags, err := i.ReleaseTags()
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if tag.Commit.Hash == head.Hash() {
+			return &tag, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,133 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGit_Changes(t *testing.T) {
+	a := assert.New(t)
+	tr := newTestRepository(t)
+	tr.commit("initial", map[string]string{".gitignore": "dist/\n", "VERSION": "1.0.0\n", "main.go": "package main\n"})
+	i := tr.instance("")
+
+	changes, err := i.Changes()
+	a.Nil(err)
+	a.Empty(changes)
+
+	a.Nil(util.WriteFile(tr.fs, "dist/app", []byte("binary"), 0644))
+	changes, err = i.Changes()
+	a.Nil(err)
+	a.Empty(changes)
+
+	a.Nil(util.WriteFile(tr.fs, "VERSION", []byte("1.1.0\n"), 0644))
+	a.Nil(util.WriteFile(tr.fs, "notes.txt", []byte("todo"), 0644))
+	a.Nil(tr.fs.Remove("main.go"))
+	changes, err = i.Changes()
+	a.Nil(err)
+	a.Equal([]string{"VERSION", "main.go", "notes.txt"}, changes)
+}
+
+func TestGit_Branch(t *testing.T) {
+	a := assert.New(t)
+	tr := newTestRepository(t)
+	hash := tr.commit("initial", map[string]string{"VERSION": "1.0.0\n"})
+	i := tr.instance("")
+
+	branch, err := i.Branch()
+	a.Nil(err)
+	a.Equal("master", branch)
+
+	wt, err := tr.repo.Worktree()
+	a.Nil(err)
+	a.Nil(wt.Checkout(&gogit.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("release/1.x"), Create: true}))
+	branch, err = i.Branch()
+	a.Nil(err)
+	a.Equal("release/1.x", branch)
+
+	a.Nil(wt.Checkout(&gogit.CheckoutOptions{Hash: hash}))
+	_, err = i.Branch()
+	a.EqualError(err, git.ErrStrDetachedHead)
+}
+
+func TestGit_BehindUpstream(t *testing.T) {
+	type test struct {
+		Upstream         bool
+		HeadCommit       int
+		UpstreamCommit   int
+		ExpectedUpstream string
+	}
+
+	suite := map[string]test{
+		"Without Upstream": {
+			HeadCommit: 0,
+		},
+		"Up To Date": {
+			Upstream:       true,
+			HeadCommit:     1,
+			UpstreamCommit: 1,
+		},
+		"Ahead": {
+			Upstream:       true,
+			HeadCommit:     1,
+			UpstreamCommit: 0,
+		},
+		"Behind": {
+			Upstream:         true,
+			HeadCommit:       0,
+			UpstreamCommit:   1,
+			ExpectedUpstream: "origin/master",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		tr := newTestRepository(t)
+		commits := []plumbing.Hash{
+			tr.commit("1.0.0", map[string]string{"VERSION": "1.0.0\n"}),
+			tr.commit("1.1.0", map[string]string{"VERSION": "1.1.0\n"}),
+		}
+		master := plumbing.NewBranchReferenceName("master")
+		a.Nil(tr.repo.Storer.SetReference(plumbing.NewHashReference(master, commits[test.HeadCommit])))
+
+		if test.Upstream {
+			cfg, err := tr.repo.Config()
+			a.Nil(err)
+			cfg.Branches["master"] = &config.Branch{Name: "master", Remote: "origin", Merge: master}
+			a.Nil(tr.repo.SetConfig(cfg))
+			a.Nil(tr.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "master"), commits[test.UpstreamCommit])))
+		}
+
+		upstream, err := tr.instance("").BehindUpstream()
+		a.Nil(err)
+		a.Equal(test.ExpectedUpstream, upstream)
+	}
+}
+
+func TestGit_HeadReleaseTag(t *testing.T) {
+	a := assert.New(t)
+	tr := newTestRepository(t)
+	released := tr.commit("1.0.0", map[string]string{"VERSION": "1.0.0\n"})
+	tr.tag("v1.0.0", released, true)
+	i := tr.instance("")
+
+	tag, err := i.HeadReleaseTag()
+	a.Nil(err)
+	a.Equal("v1.0.0", tag.Name)
+
+	unreleased := tr.commit("feat: login", map[string]string{"login.go": "package main\n"})
+	tr.tag("nightly", unreleased, false)
+	tag, err = i.HeadReleaseTag()
+	a.Nil(err)
+	a.Nil(tag)
+}
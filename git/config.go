@@ -0,0 +1,281 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/gcfg"
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-git/v5/config"
+	format "github.com/go-git/go-git/v5/plumbing/format/config"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrResolvingConfiguration = "resolving git configuration"
+
+	ErrStrFormattedReadingConfigFile = "reading git configuration file %v"
+	ErrStrFormattedIncludeDepth      = "including %v exceeds the maximum include depth of %d"
+)
+
+const (
+	// SystemConfigFile is read unless GIT_CONFIG_NOSYSTEM is set, or replaced with GIT_CONFIG_SYSTEM
+	SystemConfigFile = "/etc/gitconfig"
+	// maxIncludeDepth bounds nested include directives like git does
+	maxIncludeDepth = 10
+)
+
+// configResolver merges configuration files into a single raw configuration in the order git reads them
+type configResolver struct {
+	raw *format.Config
+	// gitDir and branch are matched by the gitdir and onbranch conditions of includeIf directives
+	gitDir string
+	branch string
+	home   string
+}
+
+// ResolveConfig returns the effective configuration of the repository whose git directory is meta.
+// The system, global, local and worktree files are read in this order, later values taking precedence, along with the files
+// of their include and includeIf directives. The GIT_AUTHOR_* and GIT_COMMITTER_* environment variables override the identity.
+func ResolveConfig(meta billy.Filesystem) (*config.Config, error) {
+	home, _ := os.UserHomeDir()
+	gitDir, err := filepath.Abs(meta.Root())
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrResolvingConfiguration)
+	}
+	r := &configResolver{
+		raw:    format.New(),
+		gitDir: gitDir,
+		branch: headBranch(meta),
+		home:   home,
+	}
+
+	for _, file := range r.userFiles() {
+		if err := r.readFile(file, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.readGitDirFile(meta, "config"); err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(r.option("extensions", "worktreeconfig"), "true") {
+		if err := r.readGitDirFile(meta, "config.worktree"); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := format.NewEncoder(&buf).Encode(r.raw); err != nil {
+		return nil, errors.Wrap(err, ErrStrResolvingConfiguration)
+	}
+	cfg, err := config.ReadConfig(&buf)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrResolvingConfiguration)
+	}
+
+	for env, value := range map[string]*string{
+		"GIT_AUTHOR_NAME":     &cfg.Author.Name,
+		"GIT_AUTHOR_EMAIL":    &cfg.Author.Email,
+		"GIT_COMMITTER_NAME":  &cfg.Committer.Name,
+		"GIT_COMMITTER_EMAIL": &cfg.Committer.Email,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*value = v
+		}
+	}
+
+	return cfg, nil
+}
+
+// userFiles returns the system and global configuration files, honoring GIT_CONFIG_NOSYSTEM, GIT_CONFIG_SYSTEM and GIT_CONFIG_GLOBAL
+func (r *configResolver) userFiles() []string {
+	files := make([]string, 0)
+
+	if noSystem := os.Getenv("GIT_CONFIG_NOSYSTEM"); noSystem == "" || noSystem == "0" || strings.EqualFold(noSystem, "false") {
+		system := os.Getenv("GIT_CONFIG_SYSTEM")
+		if system == "" {
+			system = SystemConfigFile
+		}
+		files = append(files, system)
+	}
+
+	if global := os.Getenv("GIT_CONFIG_GLOBAL"); global != "" {
+		return append(files, global)
+	}
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if xdg == "" && r.home != "" {
+		xdg = filepath.Join(r.home, ".config")
+	}
+	if xdg != "" {
+		files = append(files, filepath.Join(xdg, "git", "config"))
+	}
+	if r.home != "" {
+		files = append(files, filepath.Join(r.home, ".gitconfig"))
+	}
+
+	return files
+}
+
+// readFile reads a configuration file of the operating system, missing files are skipped
+func (r *configResolver) readFile(file string, depth int) error {
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedReadingConfigFile, file)
+	}
+	return r.read(bytes.NewReader(content), file, filepath.Dir(file), depth)
+}
+
+// readGitDirFile reads a configuration file of the git directory, missing files are skipped
+func (r *configResolver) readGitDirFile(meta billy.Filesystem, name string) error {
+	f, err := meta.Open(name)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedReadingConfigFile, name)
+	}
+	defer f.Close()
+	return r.read(f, name, r.gitDir, 0)
+}
+
+// read adds the options of a configuration file, expanding its include directives where they appear.
+// Relative include paths are relative to dir, the directory of the file.
+func (r *configResolver) read(reader io.Reader, name string, dir string, depth int) error {
+	err := gcfg.ReadWithCallback(reader, func(section string, subsection string, key string, value string, _ bool) error {
+		if subsection == "" && key == "" {
+			r.raw.Section(section)
+			return nil
+		} else if key == "" {
+			r.raw.Section(section).Subsection(subsection)
+			return nil
+		}
+		r.raw.AddOption(section, subsection, key, value)
+
+		if !strings.EqualFold(key, "path") || value == "" {
+			return nil
+		}
+		if (strings.EqualFold(section, "include") && subsection == "") ||
+			(strings.EqualFold(section, "includeIf") && r.matches(subsection, dir)) {
+			return r.include(value, dir, depth+1)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedReadingConfigFile, name)
+	}
+	return nil
+}
+
+// include reads the file of an include directive
+func (r *configResolver) include(file string, dir string, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf(ErrStrFormattedIncludeDepth, file, maxIncludeDepth)
+	}
+	file = r.expandHome(file)
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+	return r.readFile(file, depth)
+}
+
+// matches reports whether the gitdir, gitdir/i or onbranch condition of an includeIf directive holds.
+// Other conditions never hold.
+func (r *configResolver) matches(condition string, dir string) bool {
+	kind, pattern, found := strings.Cut(condition, ":")
+	if !found || pattern == "" {
+		return false
+	}
+
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	switch kind {
+	case "gitdir", "gitdir/i":
+		if strings.HasPrefix(pattern, "./") {
+			pattern = filepath.ToSlash(dir) + pattern[1:]
+		}
+		pattern = r.expandHome(pattern)
+		if !strings.HasPrefix(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+		return globMatch(pattern, filepath.ToSlash(r.gitDir), kind == "gitdir/i")
+	case "onbranch":
+		return r.branch != "" && globMatch(pattern, r.branch, false)
+	}
+
+	return false
+}
+
+// expandHome replaces a leading ~/ with the home directory
+func (r *configResolver) expandHome(file string) string {
+	if strings.HasPrefix(file, "~/") && r.home != "" {
+		return filepath.ToSlash(r.home) + file[1:]
+	}
+	return file
+}
+
+// option returns the last value of an option of the options read so far
+func (r *configResolver) option(section string, key string) string {
+	value := ""
+	for _, s := range r.raw.Sections {
+		if s.IsName(section) && s.Options.Has(key) {
+			value = s.Options.Get(key)
+		}
+	}
+	return value
+}
+
+// headBranch returns the short name of the branch HEAD refers to, or an empty string when HEAD is detached
+func headBranch(meta billy.Filesystem) string {
+	f, err := meta.Open("HEAD")
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return ""
+	}
+	branch, found := strings.CutPrefix(strings.TrimSpace(string(content)), "ref: refs/heads/")
+	if !found {
+		return ""
+	}
+	return branch
+}
+
+// globMatch matches name against a wildcard pattern of includeIf conditions, where ** also matches slashes
+func globMatch(pattern string, name string, foldCase bool) bool {
+	var expr strings.Builder
+	if foldCase {
+		expr.WriteString("(?i)")
+	}
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	expr.WriteString("$")
+
+	matched, err := regexp.MatchString(expr.String(), name)
+	return err == nil && matched
+}
@@ -0,0 +1,187 @@
+package git_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGit_ResolveConfig(t *testing.T) {
+	type test struct {
+		System   string
+		Global   string
+		Local    string
+		Worktree string
+		// Files are written relative to the home directory
+		Files              map[string]string
+		Env                map[string]string
+		ExpectedName       string
+		ExpectedEmail      string
+		ExpectedAuthor     string
+		ExpectedCommitter  string
+		ExpectedSigningKey string
+		ExpectedErrorStr   string
+	}
+
+	suite := map[string]test{
+		"Local Over Global": {
+			Global:        "[user]\n\tname = Global\n\temail = global@example.com\n",
+			Local:         "[user]\n\tname = Local\n",
+			ExpectedName:  "Local",
+			ExpectedEmail: "global@example.com",
+		},
+		"Global Over System": {
+			System:        "[user]\n\tname = System\n\temail = system@example.com\n",
+			Global:        "[user]\n\tname = Global\n",
+			ExpectedName:  "Global",
+			ExpectedEmail: "system@example.com",
+		},
+		"Worktree Over Local": {
+			Local:         "[extensions]\n\tworktreeConfig = true\n[user]\n\tname = Local\n",
+			Worktree:      "[user]\n\tname = Worktree\n",
+			ExpectedName:  "Worktree",
+			ExpectedEmail: "",
+		},
+		"Worktree Config Disabled": {
+			Local:        "[user]\n\tname = Local\n",
+			Worktree:     "[user]\n\tname = Worktree\n",
+			ExpectedName: "Local",
+		},
+		"Include": {
+			Global:             "[include]\n\tpath = ~/identity.gitconfig\n[user]\n\tname = Global\n",
+			Files:              map[string]string{"identity.gitconfig": "[user]\n\tname = Included\n\tsigningkey = ABCDEF\n"},
+			ExpectedName:       "Global",
+			ExpectedSigningKey: "ABCDEF",
+		},
+		"Relative Include": {
+			Global:       "[user]\n\tname = Global\n[include]\n\tpath = config.d/identity\n",
+			Files:        map[string]string{"config.d/identity": "[user]\n\tname = Included\n"},
+			ExpectedName: "Included",
+		},
+		"IncludeIf Gitdir": {
+			Global:        "[user]\n\temail = me@example.com\n[includeIf \"gitdir:{{work}}/\"]\n\tpath = work.gitconfig\n",
+			Files:         map[string]string{"work.gitconfig": "[user]\n\temail = me@work.example.com\n"},
+			ExpectedEmail: "me@work.example.com",
+		},
+		"IncludeIf Gitdir Pattern": {
+			Global:        "[user]\n\temail = me@example.com\n[includeIf \"gitdir/i:WORK/\"]\n\tpath = work.gitconfig\n",
+			Files:         map[string]string{"work.gitconfig": "[user]\n\temail = me@work.example.com\n"},
+			ExpectedEmail: "me@work.example.com",
+		},
+		"IncludeIf Gitdir Not Matching": {
+			Global:        "[user]\n\temail = me@example.com\n[includeIf \"gitdir:~/oss/\"]\n\tpath = work.gitconfig\n",
+			Files:         map[string]string{"work.gitconfig": "[user]\n\temail = me@work.example.com\n"},
+			ExpectedEmail: "me@example.com",
+		},
+		"IncludeIf Onbranch": {
+			Local:        "[includeIf \"onbranch:mas*\"]\n\tpath = ~/release.gitconfig\n[includeIf \"onbranch:release/\"]\n\tpath = ~/other.gitconfig\n",
+			Files:        map[string]string{"release.gitconfig": "[user]\n\tname = Release Bot\n", "other.gitconfig": "[user]\n\tname = Other\n"},
+			ExpectedName: "Release Bot",
+		},
+		"Environment": {
+			Global:            "[user]\n\tname = Global\n\temail = global@example.com\n[committer]\n\tname = Configured Committer\n",
+			Env:               map[string]string{"GIT_AUTHOR_NAME": "CI Author", "GIT_COMMITTER_EMAIL": "ci@example.com"},
+			ExpectedName:      "Global",
+			ExpectedEmail:     "global@example.com",
+			ExpectedAuthor:    "CI Author <global@example.com>",
+			ExpectedCommitter: "Configured Committer <ci@example.com>",
+		},
+		"Include Loop": {
+			Global:           "[include]\n\tpath = ~/.gitconfig\n",
+			ExpectedErrorStr: fmt.Sprintf(git.ErrStrFormattedIncludeDepth, "~/.gitconfig", 10),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		home := t.TempDir()
+		work := filepath.Join(home, "work")
+		t.Setenv("HOME", home)
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("GIT_CONFIG_GLOBAL", "")
+		for _, env := range []string{"GIT_AUTHOR_NAME", "GIT_AUTHOR_EMAIL", "GIT_COMMITTER_NAME", "GIT_COMMITTER_EMAIL"} {
+			t.Setenv(env, "")
+		}
+		for k, v := range test.Env {
+			t.Setenv(k, v)
+		}
+
+		write := func(file string, content string) {
+			content = strings.ReplaceAll(content, "{{work}}", work)
+			a.Nil(os.MkdirAll(filepath.Dir(file), 0755))
+			a.Nil(os.WriteFile(file, []byte(content), 0644))
+		}
+
+		t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+		if test.System != "" {
+			t.Setenv("GIT_CONFIG_NOSYSTEM", "")
+			t.Setenv("GIT_CONFIG_SYSTEM", filepath.Join(home, "system.gitconfig"))
+			write(filepath.Join(home, "system.gitconfig"), test.System)
+		}
+		if test.Global != "" {
+			write(filepath.Join(home, ".gitconfig"), test.Global)
+		}
+		for file, content := range test.Files {
+			write(filepath.Join(home, file), content)
+		}
+
+		_, err := gogit.PlainInit(filepath.Join(work, "app"), false)
+		a.Nil(err)
+		gitDir := filepath.Join(work, "app", ".git")
+		if test.Local != "" {
+			write(filepath.Join(gitDir, "config"), test.Local)
+		}
+		if test.Worktree != "" {
+			write(filepath.Join(gitDir, "config.worktree"), test.Worktree)
+		}
+
+		cfg, err := git.ResolveConfig(osfs.New(gitDir))
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+
+		a.Equal(test.ExpectedName, cfg.User.Name)
+		a.Equal(test.ExpectedEmail, cfg.User.Email)
+		cp := new(git.ConfigParser)
+		cp.SetConfig(cfg)
+		a.Equal(test.ExpectedSigningKey, cp.GetSectionOption("user", "signingkey"))
+
+		if test.ExpectedAuthor != "" {
+			tr := newTestRepository(t)
+			tr.commit("initial", map[string]string{"VERSION": "1.0.0\n"})
+			i := tr.instance("")
+			i.Config = cfg
+			a.Nil(util.WriteFile(tr.fs, "VERSION", []byte("1.1.0\n"), 0644))
+			r := &git.Release{Version: "1.1.0", Files: []string{"VERSION"}}
+			a.Nil(i.Save(r, nil))
+			a.Equal(test.ExpectedAuthor, r.Author)
+
+			head, err := tr.repo.Head()
+			a.Nil(err)
+			commit, err := tr.repo.CommitObject(head.Hash())
+			a.Nil(err)
+			a.Equal(test.ExpectedAuthor, fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email))
+			a.Equal(test.ExpectedCommitter, fmt.Sprintf("%s <%s>", commit.Committer.Name, commit.Committer.Email))
+
+			ref, err := tr.repo.Tag("v1.1.0")
+			a.Nil(err)
+			tag, err := tr.repo.TagObject(ref.Hash())
+			a.Nil(err)
+			a.Equal(test.ExpectedCommitter, fmt.Sprintf("%s <%s>", tag.Tagger.Name, tag.Tagger.Email))
+		}
+	}
+}
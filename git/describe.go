@@ -0,0 +1,69 @@
+package git
+
+import (
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/pkg/errors"
+)
+
+// ShortHashLength is the length of the abbreviated commit hashes of descriptions
+const ShortHashLength = 7
+
+// DescribeConfig used to parse the [describe] section of the .bump toml file
+type DescribeConfig struct {
+	// Format is the Go template of described versions, e.g. {{.Next}}-dev.{{.Distance}}+g{{.Hash}}
+	Format string
+}
+
+// Description locates HEAD relatively to the latest release tag
+type Description struct {
+	// Tag is the release tag with the highest version reachable from HEAD, or nil
+	Tag *ReleaseTag
+	// Distance is the number of commits since Tag, or since the first commit
+	Distance int
+	Hash     plumbing.Hash
+	// Dirty reports whether tracked files of the working tree are modified
+	Dirty bool
+}
+
+// Describe describes HEAD relatively to the release tag with the highest version reachable from it
+func (i *Instance) Describe() (*Description, error) {
+	tag, err := i.LatestReachableTag()
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := i.CommitsSince(tag, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := i.Repository.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingHead)
+	}
+
+	status, err := i.Worktree.Status()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingStatus)
+	}
+	dirty := false
+	for _, s := range status {
+		if s.Worktree == git.Untracked {
+			continue
+		}
+		dirty = dirty || s.Staging != git.Unmodified || s.Worktree != git.Unmodified
+	}
+
+	return &Description{
+		Tag:      tag,
+		Distance: len(commits),
+		Hash:     head.Hash(),
+		Dirty:    dirty,
+	}, nil
+}
+
+// ShortHash returns the abbreviated hash of HEAD
+func (d *Description) ShortHash() string {
+	return d.Hash.String()[:ShortHashLength]
+}
@@ -0,0 +1,43 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGit_Describe(t *testing.T) {
+	a := assert.New(t)
+	tr := newTestRepository(t)
+	tr.commit("initial", map[string]string{"main.go": "package main\n"})
+	i := tr.instance("")
+
+	d, err := i.Describe()
+	a.Nil(err)
+	a.Nil(d.Tag)
+	a.Equal(1, d.Distance)
+
+	released := tr.commit("1.0.0", map[string]string{"VERSION": "1.0.0\n"})
+	tr.tag("v1.0.0", released, true)
+	d, err = i.Describe()
+	a.Nil(err)
+	a.Equal("v1.0.0", d.Tag.Name)
+	a.Equal(0, d.Distance)
+	a.Equal(released, d.Hash)
+	a.False(d.Dirty)
+
+	tr.commit("fix: crash", map[string]string{"main.go": "package main\n\nfunc main() {}\n"})
+	head := tr.commit("feat: login", map[string]string{"login.go": "package main\n"})
+	a.Nil(util.WriteFile(tr.fs, "notes.txt", []byte("todo"), 0644))
+	d, err = i.Describe()
+	a.Nil(err)
+	a.Equal(2, d.Distance)
+	a.Equal(head.String()[:7], d.ShortHash())
+	a.False(d.Dirty)
+
+	a.Nil(util.WriteFile(tr.fs, "login.go", []byte("package app\n"), 0644))
+	d, err = i.Describe()
+	a.Nil(err)
+	a.True(d.Dirty)
+}
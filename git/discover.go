@@ -0,0 +1,127 @@
+package git
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/osfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/filesystem/dotgit"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrDiscoveringRepository = "discovering repository"
+
+	ErrStrFormattedReadingGitDirFile = "reading git directory from %v"
+)
+
+const (
+	// GitDirName is the directory, or the file pointing to it for linked worktrees and submodules, at the root of a working tree
+	GitDirName = ".git"
+	// gitDirPrefix starts the single line of .git files
+	gitDirPrefix = "gitdir:"
+	// commonDirFile in the git directory of a linked worktree points to the git directory of the main working tree
+	commonDirFile = "commondir"
+)
+
+// Location is a repository discovered from a directory of its working tree
+type Location struct {
+	// Root is the top directory of the working tree
+	Root string
+	// GitDir is the git directory of the working tree, e.g. .git/worktrees/<name> for linked worktrees
+	GitDir string
+	// CommonDir holds the objects, references and configuration shared by linked worktrees, it is GitDir otherwise
+	CommonDir string
+}
+
+// Discover walks up from dir to the first directory containing .git. A .git file, as found in linked worktrees and submodules,
+// is resolved to the git directory it points to, along with the common directory of linked worktrees.
+func Discover(dir string) (*Location, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrDiscoveringRepository)
+	}
+
+	for {
+		dotGit := filepath.Join(current, GitDirName)
+		info, err := os.Stat(dotGit)
+		if err == nil {
+			if info.IsDir() {
+				return &Location{Root: current, GitDir: dotGit, CommonDir: dotGit}, nil
+			}
+			return locationFromFile(current, dotGit)
+		} else if !os.IsNotExist(err) {
+			return nil, errors.Wrap(err, ErrStrDiscoveringRepository)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return nil, errors.Wrap(gogit.ErrRepositoryNotExists, ErrStrOpeningRepo)
+		}
+		current = parent
+	}
+}
+
+// Meta returns the git directory of the location, reading the shared files of linked worktrees from their common directory
+func (l *Location) Meta() billy.Filesystem {
+	if l.CommonDir == "" || l.CommonDir == l.GitDir {
+		return osfs.New(l.GitDir)
+	}
+	return dotgit.NewRepositoryFilesystem(osfs.New(l.GitDir), osfs.New(l.CommonDir))
+}
+
+// Data returns the working tree of the location
+func (l *Location) Data() billy.Filesystem {
+	return osfs.New(l.Root)
+}
+
+// locationFromFile resolves the gitdir: line of a .git file, relative to the directory of the file
+func locationFromFile(root string, file string) (*Location, error) {
+	gitDir, err := readPath(file, gitDirPrefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, ErrStrFormattedReadingGitDirFile, file)
+	} else if gitDir == "" {
+		return nil, errors.Wrapf(gogit.ErrRepositoryNotExists, ErrStrFormattedReadingGitDirFile, file)
+	}
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(root, gitDir)
+	}
+
+	gitDir = filepath.Clean(gitDir)
+	return &Location{Root: root, GitDir: gitDir, CommonDir: commonDir(gitDir)}, nil
+}
+
+// commonDir returns the directory the commondir file of gitDir points to, or gitDir itself when there is none
+func commonDir(gitDir string) string {
+	common, err := readPath(filepath.Join(gitDir, commonDirFile), "")
+	if err != nil || common == "" {
+		return gitDir
+	}
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitDir, common)
+	}
+	return filepath.Clean(common)
+}
+
+// readPath returns the first line of file without prefix, which is empty when the line does not start with prefix
+func readPath(file string, prefix string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return "", scanner.Err()
+	}
+	line, found := strings.CutPrefix(strings.TrimSpace(scanner.Text()), prefix)
+	if !found {
+		return "", nil
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,121 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGit_Discover(t *testing.T) {
+	type test struct {
+		// Setup lays out files below a temporary directory holding a repository at main, with a commit on the branch feature
+		Setup             func(a *assert.Assertions, tmp string)
+		Dir               string
+		ExpectedRoot      string
+		ExpectedGitDir    string
+		ExpectedCommonDir string
+		ExpectedHead      string
+		ExpectedErrorStr  string
+	}
+
+	worktree := func(a *assert.Assertions, tmp string) {
+		gitDir := filepath.Join(tmp, "main", ".git", "worktrees", "wt")
+		a.Nil(os.MkdirAll(gitDir, 0755))
+		a.Nil(os.WriteFile(filepath.Join(gitDir, "HEAD"), []byte("ref: refs/heads/feature\n"), 0644))
+		a.Nil(os.WriteFile(filepath.Join(gitDir, "commondir"), []byte("../..\n"), 0644))
+		a.Nil(os.MkdirAll(filepath.Join(tmp, "wt", "src"), 0755))
+		a.Nil(os.WriteFile(filepath.Join(tmp, "wt", ".git"), []byte("gitdir: "+gitDir+"\n"), 0644))
+	}
+
+	submodule := func(a *assert.Assertions, tmp string) {
+		a.Nil(os.Rename(filepath.Join(tmp, "main", ".git"), filepath.Join(tmp, "modules-sub")))
+		a.Nil(os.WriteFile(filepath.Join(tmp, "main", ".git"), []byte("gitdir: ../modules-sub\n"), 0644))
+	}
+
+	suite := map[string]test{
+		"Root": {
+			Dir:               "main",
+			ExpectedRoot:      "main",
+			ExpectedGitDir:    "main/.git",
+			ExpectedCommonDir: "main/.git",
+			ExpectedHead:      "refs/heads/master",
+		},
+		"Subdirectory": {
+			Dir:               "main/src/pkg",
+			ExpectedRoot:      "main",
+			ExpectedGitDir:    "main/.git",
+			ExpectedCommonDir: "main/.git",
+			ExpectedHead:      "refs/heads/master",
+		},
+		"Linked Worktree": {
+			Setup:             worktree,
+			Dir:               "wt/src",
+			ExpectedRoot:      "wt",
+			ExpectedGitDir:    "main/.git/worktrees/wt",
+			ExpectedCommonDir: "main/.git",
+			ExpectedHead:      "refs/heads/feature",
+		},
+		"Submodule": {
+			Setup:             submodule,
+			Dir:               "main/src",
+			ExpectedRoot:      "main",
+			ExpectedGitDir:    "modules-sub",
+			ExpectedCommonDir: "modules-sub",
+			ExpectedHead:      "refs/heads/master",
+		},
+		"Not A Repository": {
+			Dir:              "outside",
+			ExpectedErrorStr: git.ErrStrOpeningRepo + ": repository does not exist",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		tmp := t.TempDir()
+		repo, err := gogit.PlainInit(filepath.Join(tmp, "main"), false)
+		a.Nil(err)
+		a.Nil(os.MkdirAll(filepath.Join(tmp, "main", "src", "pkg"), 0755))
+		a.Nil(os.MkdirAll(filepath.Join(tmp, "outside"), 0755))
+		a.Nil(os.WriteFile(filepath.Join(tmp, "main", "VERSION"), []byte("1.0.0\n"), 0644))
+		wt, err := repo.Worktree()
+		a.Nil(err)
+		_, err = wt.Add("VERSION")
+		a.Nil(err)
+		hash, err := wt.Commit("initial", &gogit.CommitOptions{
+			Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+		})
+		a.Nil(err)
+		a.Nil(repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), hash)))
+		if test.Setup != nil {
+			test.Setup(a, tmp)
+		}
+
+		location, err := git.Discover(filepath.Join(tmp, test.Dir))
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+		a.Equal(filepath.Join(tmp, test.ExpectedRoot), location.Root)
+		a.Equal(filepath.Join(tmp, test.ExpectedGitDir), location.GitDir)
+		a.Equal(filepath.Join(tmp, test.ExpectedCommonDir), location.CommonDir)
+
+		i, err := git.New(location.Meta(), location.Data())
+		a.Nil(err)
+		head, err := i.Repository.Head()
+		a.Nil(err)
+		a.Equal(test.ExpectedHead, head.Name().String())
+		a.Equal(hash, head.Hash())
+	}
+}
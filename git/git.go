@@ -7,46 +7,94 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing/cache"
 	"github.com/go-git/go-git/v5/storage/filesystem"
+	"strings"
 	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/index"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+	"slices"
+	"sort"
 )
 
 var (
-	ErrStrOpeningRepo                   = "opening repository"
-	ErrStrRetrievingGlobalConfiguration = "retrieving global git configuration"
-	ErrStrRetrievingWorkTree            = "retrieving git worktree"
-	ErrStrCommittingChanges             = "committing changes"
-	ErrStrTaggingChanges                = "tagging changes"
-	ErrStrLoadingConfiguration          = "loading git configuration from global scope"
-
-	ErrStrFormattedStagingAFile = "staging a file %s"
+	ErrStrOpeningRepo        = "opening repository"
+	ErrStrRetrievingWorkTree = "retrieving git worktree"
+	ErrStrCommittingChanges  = "committing changes"
+	ErrStrTaggingChanges     = "tagging changes"
+	ErrStrUnstagingChanges   = "unstaging changes unrelated to the release"
+
+	ErrStrFormattedStagingAFile   = "staging a file %s"
+	ErrStrFormattedMovingAliasTag = "moving alias tag %s"
+	ErrStrFormattedRestagingAFile = "staging again a file %s"
 )
 
 const (
 	Username string = "username"
 	Email    string = "username@domain.com"
+
+	// DefaultTagTemplate is the name of created tags when no TagTemplate is set
+	DefaultTagTemplate string = "v{{version}}"
 )
 
 type Instance struct {
 	Repository RepositoryInterface
 	Worktree   WorktreeInterface
 	Config     *config.Config
+	// TagTemplate is the Go template of the name of created tags, e.g. tools/cli/v{{version}}
+	TagTemplate string
+	// CommitMessageTemplate is the Go template of the release commit message, e.g. release: v{{.Version}} [skip ci]
+	CommitMessageTemplate string
+	// TagMessageTemplate is the Go template of the message of annotated release tags
+	TagMessageTemplate string
+	// LightweightTags creates lightweight instead of annotated tags, unless they are signed
+	LightweightTags bool
+	// AliasTagTemplates are the Go templates of tags moved to each release, e.g. v{{major}} and v{{major}}.{{minor}}
+	AliasTagTemplates []string
+	// IncludeStaged commits the changes staged before the release along with the bumped files
+	IncludeStaged bool
+	// RunHooks runs the pre-commit, commit-msg and post-commit hooks of the repository around release commits
+	RunHooks bool
+	// GitDir and Dir are the git directory and the root of the working tree on disk, where hooks run
+	GitDir string
+	Dir    string
+	// References stores the release branches, it is the storer of Repository
+	References storer.ReferenceStorer
+	// Index stores the index, it is the storer of Repository
+	Index storer.IndexStorer
+}
+
+// Tag is an additional annotated tag created on a release commit
+type Tag struct {
+	Name    string
+	Message string
 }
 
 type RepositoryInterface interface {
 	Worktree() (*git.Worktree, error)
 	CreateTag(string, plumbing.Hash, *git.CreateTagOptions) (*plumbing.Reference, error)
+	DeleteTag(string) error
+	Remote(string) (*git.Remote, error)
+	Push(*git.PushOptions) error
+	Reference(plumbing.ReferenceName, bool) (*plumbing.Reference, error)
 	ConfigScoped(config.Scope) (*config.Config, error)
+	Head() (*plumbing.Reference, error)
+	Tags() (storer.ReferenceIter, error)
+	TagObject(plumbing.Hash) (*object.Tag, error)
+	CommitObject(plumbing.Hash) (*object.Commit, error)
+	Log(*git.LogOptions) (object.CommitIter, error)
 }
 
 type WorktreeInterface interface {
 	Add(string) (plumbing.Hash, error)
 	Commit(string, *git.CommitOptions) (plumbing.Hash, error)
+	Status() (git.Status, error)
+	Reset(*git.ResetOptions) error
 }
 
 func New(meta billy.Filesystem, data billy.Filesystem) (*Instance, error) {
@@ -54,7 +102,7 @@ func New(meta billy.Filesystem, data billy.Filesystem) (*Instance, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, ErrStrOpeningRepo)
 	}
-	return GetInstanceFromRepo(repo)
+	return GetInstanceFromRepo(repo, meta)
 }
 
 func GetRepoFromFileSystem(meta billy.Filesystem, data billy.Filesystem) (*git.Repository, error) {
@@ -64,10 +112,11 @@ func GetRepoFromFileSystem(meta billy.Filesystem, data billy.Filesystem) (*git.R
 	)
 }
 
-func GetInstanceFromRepo(repo RepositoryInterface) (*Instance, error) {
-	gitConfig, err := repo.ConfigScoped(config.GlobalScope)
+// GetInstanceFromRepo returns an Instance of repo whose configuration is resolved from every scope, see ResolveConfig
+func GetInstanceFromRepo(repo RepositoryInterface, meta billy.Filesystem) (*Instance, error) {
+	gitConfig, err := ResolveConfig(meta)
 	if err != nil {
-		return nil, errors.Wrap(err, ErrStrRetrievingGlobalConfiguration)
+		return nil, err
 	}
 
 	worktree, err := repo.Worktree()
@@ -75,70 +124,343 @@ func GetInstanceFromRepo(repo RepositoryInterface) (*Instance, error) {
 		return nil, errors.Wrap(err, ErrStrRetrievingWorkTree)
 	}
 
-	return &Instance{
+	instance := &Instance{
 		Repository: repo,
 		Worktree:   worktree,
 		Config:     gitConfig,
-	}, nil
+		GitDir:     meta.Root(),
+	}
+	if worktree.Filesystem != nil {
+		instance.Dir = worktree.Filesystem.Root()
+	}
+	if r, ok := repo.(*git.Repository); ok {
+		instance.References = r.Storer
+		instance.Index = r.Storer
+	}
+
+	return instance, nil
 }
 
-func (i *Instance) Save(files []string, version string, gpgEntity *openpgp.Entity) error {
-	tm := time.Now()
-	sign := &object.Signature{
-		Name:  i.Config.User.Name,
-		Email: i.Config.User.Email,
-		When:  tm,
+// Save commits the files of release and tags the commit with its version.
+// The commit message, tag name and tag message are rendered from the templates of the instance.
+// Any additional tags are created on the same commit, and existing alias tags are moved to it.
+func (i *Instance) Save(release *Release, gpgEntity *openpgp.Entity, tags ...Tag) error {
+	sign := i.signature(release)
+
+	commitMessage, err := i.CommitMessage(release)
+	if err != nil {
+		return errors.Wrap(err, ErrStrCommittingChanges)
+	}
+	tagMessage, aliases, err := i.releaseTagMessageAndAliases(release)
+	if err != nil {
+		return err
 	}
 
-	hash, err := i.Commit(files, version, sign, gpgEntity)
+	hash, err := i.Commit(release.Files, commitMessage, sign, gpgEntity)
 	if err != nil {
 		return err
 	}
 
-	_, err = i.Repository.CreateTag(fmt.Sprintf("v%v", version), hash, &git.CreateTagOptions{
-		Tagger:  sign,
-		Message: version,
-		SignKey: gpgEntity,
-	})
+	return i.createReleaseTags(hash, release, tagMessage, aliases, sign, gpgEntity, tags)
+}
+
+// Tag tags HEAD with the version of release without committing anything, along with any additional tags and the alias tags
+func (i *Instance) Tag(release *Release, gpgEntity *openpgp.Entity, tags ...Tag) error {
+	sign := i.signature(release)
+
+	tagMessage, aliases, err := i.releaseTagMessageAndAliases(release)
 	if err != nil {
-		return errors.Wrap(err, ErrStrTaggingChanges)
+		return err
+	}
+
+	head, err := i.Repository.Head()
+	if err != nil {
+		return errors.Wrap(err, ErrStrReadingHead)
+	}
+
+	return i.createReleaseTags(head.Hash(), release, tagMessage, aliases, sign, gpgEntity, tags)
+}
+
+// signature returns the signature of the author, and completes release with its date, author and tag name
+func (i *Instance) signature(release *Release) *object.Signature {
+	tm := time.Now()
+	sign := i.author(tm)
+
+	if release.Date.IsZero() {
+		release.Date = tm
+	}
+	release.Author = fmt.Sprintf("%s <%s>", sign.Name, sign.Email)
+	release.Tag = i.TagName(release.Version)
+
+	return sign
+}
+
+// author returns the signature of the configured author, or of the user
+func (i *Instance) author(when time.Time) *object.Signature {
+	return identity(i.Config.Author.Name, i.Config.Author.Email, i.Config, when)
+}
+
+// committer returns the signature of the configured committer, or of the user. Tags are created by the committer.
+func (i *Instance) committer(when time.Time) *object.Signature {
+	return identity(i.Config.Committer.Name, i.Config.Committer.Email, i.Config, when)
+}
+
+// identity returns a signature of name and email, falling back to the user of cfg for each of them
+func identity(name string, email string, cfg *config.Config, when time.Time) *object.Signature {
+	if name == "" {
+		name = cfg.User.Name
+	}
+	if email == "" {
+		email = cfg.User.Email
+	}
+	return &object.Signature{Name: name, Email: email, When: when}
+}
+
+// releaseTagMessageAndAliases renders the tag message and the alias tags of release
+func (i *Instance) releaseTagMessageAndAliases(release *Release) (string, []string, error) {
+	tagMessage, err := i.TagMessage(release)
+	if err != nil {
+		return "", nil, errors.Wrap(err, ErrStrTaggingChanges)
+	}
+
+	aliases, err := i.AliasTags(release)
+	if err != nil {
+		return "", nil, errors.Wrap(err, ErrStrTaggingChanges)
+	}
+
+	return tagMessage, aliases, nil
+}
+
+// createReleaseTags creates the tag of release and tags on hash, and moves the alias tags to it
+func (i *Instance) createReleaseTags(hash plumbing.Hash, release *Release, tagMessage string, aliases []string, sign *object.Signature, gpgEntity *openpgp.Entity, tags []Tag) error {
+	tagger := i.committer(sign.When)
+	tags = append([]Tag{{Name: release.Tag, Message: tagMessage}}, tags...)
+	for _, tag := range tags {
+		if err := i.createTag(tag, hash, tagger, gpgEntity); err != nil {
+			return errors.Wrap(err, ErrStrTaggingChanges)
+		}
+	}
+
+	for _, alias := range aliases {
+		err := i.Repository.DeleteTag(alias)
+		if err != nil && !errors.Is(err, git.ErrTagNotFound) {
+			return errors.Wrapf(err, ErrStrFormattedMovingAliasTag, alias)
+		}
+		if err := i.createTag(Tag{Name: alias, Message: tagMessage}, hash, tagger, gpgEntity); err != nil {
+			return errors.Wrapf(err, ErrStrFormattedMovingAliasTag, alias)
+		}
 	}
 
 	return nil
 }
 
-func (i *Instance) Commit(files []string, version string, sign *object.Signature, entity *openpgp.Entity) (plumbing.Hash, error) {
+// AliasTags returns the names of the alias tags moved to release. Prereleases have no alias tags.
+func (i *Instance) AliasTags(release *Release) ([]string, error) {
+	aliases := make([]string, 0)
+	if semver.Prerelease("v"+release.Version) != "" {
+		return aliases, nil
+	}
+	for _, t := range i.AliasTagTemplates {
+		alias, err := Render(t, release)
+		if err != nil {
+			return nil, err
+		}
+		alias = strings.TrimSpace(alias)
+		if alias == "" || alias == release.Tag || slices.Contains(aliases, alias) {
+			continue
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, nil
+}
+
+// createTag creates an annotated tag, signed when gpgEntity is set, or a lightweight tag when LightweightTags is set and the tag is not signed
+func (i *Instance) createTag(tag Tag, hash plumbing.Hash, sign *object.Signature, gpgEntity *openpgp.Entity) error {
+	opts := &git.CreateTagOptions{
+		Tagger:  sign,
+		Message: tag.Message,
+		SignKey: gpgEntity,
+	}
+	if i.LightweightTags && gpgEntity == nil {
+		opts = nil
+	}
+	_, err := i.Repository.CreateTag(tag.Name, hash, opts)
+	return err
+}
+
+// TagName returns the name of the tag created for version
+func (i *Instance) TagName(version string) string {
+	return TagName(i.TagTemplate, version)
+}
+
+// TagName returns the name of the tag created for version from tagTemplate, or DefaultTagTemplate when empty
+func TagName(tagTemplate string, version string) string {
+	if tagTemplate == "" {
+		tagTemplate = DefaultTagTemplate
+	}
+	name, err := Render(tagTemplate, &Release{Version: version})
+	if err != nil {
+		// templates are validated when the configuration is loaded
+		return strings.ReplaceAll(tagTemplate, "{{version}}", version)
+	}
+	return name
+}
+
+// Commit commits exactly files with message. Changes staged beforehand are left out of the commit and staged again
+// afterwards as they were, unless IncludeStaged is set.
+// With RunHooks, a failing pre-commit or commit-msg hook returns a HookError and leaves the index as it was.
+func (i *Instance) Commit(files []string, message string, sign *object.Signature, entity *openpgp.Entity) (plumbing.Hash, error) {
+	staged, err := i.stagedChanges(files)
+	if err != nil {
+		return plumbing.Hash{}, errors.Wrap(err, ErrStrUnstagingChanges)
+	}
+	entries, err := i.indexEntries(staged)
+	if err != nil {
+		return plumbing.Hash{}, errors.Wrap(err, ErrStrUnstagingChanges)
+	}
+
+	restage := make([]string, 0)
+	if !i.IncludeStaged && len(staged) > 0 {
+		if err := i.Worktree.Reset(&git.ResetOptions{Mode: git.MixedReset}); err != nil {
+			return plumbing.Hash{}, errors.Wrap(err, ErrStrUnstagingChanges)
+		}
+		restage = staged
+	}
+
 	for _, f := range files {
-		_, err := i.Worktree.Add(f)
+		if _, err := i.Worktree.Add(f); err != nil {
+			return plumbing.Hash{}, i.restoreStaged(errors.Wrapf(err, ErrStrFormattedStagingAFile, f), staged, entries)
+		}
+	}
+
+	if i.RunHooks {
+		var hookMessage string
+		hookMessage, err = i.runCommitHooks(message)
 		if err != nil {
-			return plumbing.Hash{}, errors.Wrapf(err, ErrStrFormattedStagingAFile, f)
+			return plumbing.Hash{}, i.unstage(err, staged, entries)
 		}
+		message = hookMessage
 	}
-	hash, err := i.Worktree.Commit(version, &git.CommitOptions{
-		All:       true,
+
+	hash, err := i.Worktree.Commit(message, &git.CommitOptions{
 		Author:    sign,
-		Committer: sign,
+		Committer: i.committer(sign.When),
 		SignKey:   entity,
 	})
 	if err != nil {
-		return plumbing.Hash{}, errors.Wrap(err, ErrStrCommittingChanges)
+		return plumbing.Hash{}, i.restoreStaged(errors.Wrap(err, ErrStrCommittingChanges), staged, entries)
+	}
+
+	if err := i.restage(restage, entries); err != nil {
+		return plumbing.Hash{}, err
+	}
+
+	if i.RunHooks {
+		i.runPostCommitHook()
 	}
 
 	return hash, nil
 }
 
-func (i *Instance) GetSigningKeyFromConfig(configParser ConfigParserInterface) (string, error) {
-	configParser.SetConfig(i.Config)
-	shouldNotSign, gpgVerificationKey := getSigningKeyFromConfig(configParser)
+// unstage resets the index to HEAD after a failed commit, and stages staged again, returning the error of the commit
+func (i *Instance) unstage(commitErr error, staged []string, entries map[string]*index.Entry) error {
+	if err := i.Worktree.Reset(&git.ResetOptions{Mode: git.MixedReset}); err != nil {
+		return errors.Wrap(err, ErrStrUnstagingChanges)
+	}
+	if err := i.restage(staged, entries); err != nil {
+		return err
+	}
+	return commitErr
+}
 
-	if !shouldNotSign && gpgVerificationKey == "" {
-		gitConfig, err := configParser.LoadConfig(config.GlobalScope)
-		if err != nil {
-			return "", errors.Wrap(err, ErrStrLoadingConfiguration)
+// restoreStaged unstages the release files after a failed commit when changes were staged beforehand, so that they are not lost
+func (i *Instance) restoreStaged(commitErr error, staged []string, entries map[string]*index.Entry) error {
+	if len(staged) == 0 {
+		return commitErr
+	}
+	return i.unstage(commitErr, staged, entries)
+}
+
+// indexEntries returns the index entries of files, without an entry for the files staged for deletion.
+// Nothing is returned without an index storer, and files are then staged again from the working tree.
+func (i *Instance) indexEntries(files []string) (map[string]*index.Entry, error) {
+	if i.Index == nil || len(files) == 0 {
+		return nil, nil
+	}
+	idx, err := i.Index.Index()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*index.Entry)
+	for _, f := range files {
+		if e, err := idx.Entry(f); err == nil {
+			entry := *e
+			entries[f] = &entry
+		} else if !errors.Is(err, index.ErrEntryNotFound) {
+			return nil, err
 		}
-		configParser.SetConfig(gitConfig)
-		_, gpgVerificationKey = getSigningKeyFromConfig(configParser)
 	}
+	return entries, nil
+}
+
+// restage writes back the index entries of files, so that partially staged files keep their staged content
+func (i *Instance) restage(files []string, entries map[string]*index.Entry) error {
+	if len(files) == 0 {
+		return nil
+	}
+	if i.Index == nil {
+		for _, f := range files {
+			if _, err := i.Worktree.Add(f); err != nil {
+				return errors.Wrapf(err, ErrStrFormattedRestagingAFile, f)
+			}
+		}
+		return nil
+	}
+
+	idx, err := i.Index.Index()
+	if err != nil {
+		return errors.Wrap(err, ErrStrUnstagingChanges)
+	}
+	for _, f := range files {
+		if _, err := idx.Remove(f); err != nil && !errors.Is(err, index.ErrEntryNotFound) {
+			return errors.Wrapf(err, ErrStrFormattedRestagingAFile, f)
+		}
+		if e, ok := entries[f]; ok {
+			entry := *e
+			idx.Entries = append(idx.Entries, &entry)
+		}
+	}
+	// the cached trees no longer match the entries
+	idx.Cache = nil
+	if err := i.Index.SetIndex(idx); err != nil {
+		return errors.Wrap(err, ErrStrUnstagingChanges)
+	}
+	return nil
+}
+
+// stagedChanges returns the staged files which are not part of files
+func (i *Instance) stagedChanges(files []string) ([]string, error) {
+	status, err := i.Worktree.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	staged := make([]string, 0)
+	for file, s := range status {
+		if s.Staging != git.Unmodified && s.Staging != git.Untracked && !slices.Contains(files, file) {
+			staged = append(staged, file)
+		}
+	}
+	sort.Strings(staged)
+
+	return staged, nil
+}
+
+// GetSigningKeyFromConfig returns the signing key of the git configuration, merged from every scope, when commits are signed
+func (i *Instance) GetSigningKeyFromConfig(configParser ConfigParserInterface) (string, error) {
+	configParser.SetConfig(i.Config)
+	_, gpgVerificationKey := getSigningKeyFromConfig(configParser)
 
 	return gpgVerificationKey, nil
 }
@@ -5,9 +5,15 @@ import (
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/nidhhoggr/version-bump/git"
+	"io"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/nidhhoggr/version-bump/mocks"
@@ -20,13 +26,22 @@ func TestGit_Save(t *testing.T) {
 	a := assert.New(t)
 
 	type test struct {
-		Version            string
-		Files              []string
-		MockWorktreeError  error
-		MockCommitOutput   plumbing.Hash
-		MockCommitError    error
-		MockCreateTagError error
-		ExpectedError      string
+		Version               string
+		OldVersion            string
+		Changelog             string
+		TagTemplate           string
+		CommitMessageTemplate string
+		TagMessageTemplate    string
+		ExpectedTagName       string
+		ExpectedCommitMessage string
+		ExpectedTagMessage    string
+		Tags                  []git.Tag
+		Files                 []string
+		MockWorktreeError     error
+		MockCommitOutput      plumbing.Hash
+		MockCommitError       error
+		MockCreateTagError    error
+		ExpectedError         string
 	}
 
 	suite := map[string]test{
@@ -38,6 +53,56 @@ func TestGit_Save(t *testing.T) {
 			},
 			MockCommitOutput: plumbing.NewHash("abc"),
 		},
+		"Success With Tag Template": {
+			Version:     "1.4.0",
+			TagTemplate: "tools/cli/v{{version}}",
+			Files: []string{
+				"tools/cli/main.go",
+			},
+			MockCommitOutput: plumbing.NewHash("abc"),
+		},
+		"Success With Additional Tags": {
+			Version:     "1.3.0",
+			TagTemplate: "api-v{{version}}",
+			Tags: []git.Tag{
+				{Name: "web-v4.0.1", Message: "4.0.1"},
+			},
+			Files: []string{
+				"services/api/package.json",
+				"services/web/package.json",
+			},
+			MockCommitOutput: plumbing.NewHash("abc"),
+		},
+		"Success With Changelog Tag Message": {
+			Version:            "1.3.0",
+			Changelog:          "## [1.3.0] - 2026-10-18\n\n### Features\n\n- add login (abc1234)\n",
+			TagMessageTemplate: "{{.Changelog}}",
+			ExpectedTagMessage: "## [1.3.0] - 2026-10-18\n\n### Features\n\n- add login (abc1234)\n",
+			Files: []string{
+				"CHANGELOG.md",
+				"VERSION",
+			},
+			MockCommitOutput: plumbing.NewHash("abc"),
+		},
+		"Success With Templates": {
+			Version:               "1.3.0",
+			OldVersion:            "1.2.9",
+			TagTemplate:           "{{.Version}}",
+			CommitMessageTemplate: "release: {{.Tag}} [skip ci]\n\nFrom {{.OldVersion}}, {{len .Files}} files\n\nSigned-off-by: {{.Author}}",
+			TagMessageTemplate:    "Release {{version}} on {{.Date.Year}}",
+			ExpectedTagName:       "1.3.0",
+			ExpectedCommitMessage: fmt.Sprintf("release: 1.3.0 [skip ci]\n\nFrom 1.2.9, 1 files\n\nSigned-off-by: %s <%s>", git.Username, git.Email),
+			ExpectedTagMessage:    fmt.Sprintf("Release 1.3.0 on %d", time.Now().Year()),
+			Files: []string{
+				"VERSION",
+			},
+			MockCommitOutput: plumbing.NewHash("abc"),
+		},
+		"Error Rendering Commit Message": {
+			Version:               "1.3.0",
+			CommitMessageTemplate: "release: {{.Missing}}",
+			ExpectedError:         fmt.Sprintf("%s: %s: template: :1:11: executing \"\" at <.Missing>: can't evaluate field Missing in type *git.Release", git.ErrStrCommittingChanges, fmt.Sprintf(git.ErrStrFormattedRenderingTemplate, "release: {{.Missing}}")),
+		},
 		"Error Tagging Commit": {
 			Version: "1.0.0",
 			Files: []string{
@@ -70,23 +135,57 @@ func TestGit_Save(t *testing.T) {
 			m2.On("Add", f).Return(nil, nil).Once()
 		}
 
-		m2.On("Commit", test.Version, mock.AnythingOfType("*git.CommitOptions")).Return(test.MockCommitOutput, test.MockCommitError).Once()
+		commitMessage := test.Version
+		if test.ExpectedCommitMessage != "" {
+			commitMessage = test.ExpectedCommitMessage
+		}
+		m2.On("Status").Return(gogit.Status{}, nil).Once()
+		m2.On("Commit", commitMessage, mock.AnythingOfType("*git.CommitOptions")).Return(test.MockCommitOutput, test.MockCommitError).Once()
 
-		m1.On("CreateTag", fmt.Sprintf("v%v", test.Version), test.MockCommitOutput, mock.AnythingOfType("*git.CreateTagOptions")).Return(nil, test.MockCreateTagError).Once()
+		tagName := fmt.Sprintf("v%v", test.Version)
+		if test.ExpectedTagName != "" {
+			tagName = test.ExpectedTagName
+		} else if test.TagTemplate != "" {
+			tagName = strings.ReplaceAll(test.TagTemplate, "{{version}}", test.Version)
+		}
+		tagMessage := test.Version
+		if test.ExpectedTagMessage != "" {
+			tagMessage = test.ExpectedTagMessage
+		}
+		m1.On("CreateTag", tagName, test.MockCommitOutput, mock.MatchedBy(func(o *gogit.CreateTagOptions) bool {
+			return o.Message == tagMessage
+		})).Return(nil, test.MockCreateTagError).Once()
+		for _, tag := range test.Tags {
+			m1.On("CreateTag", tag.Name, test.MockCommitOutput, mock.MatchedBy(func(o *gogit.CreateTagOptions) bool {
+				return o.Message == tag.Message
+			})).Return(nil, nil).Once()
+		}
 
 		gitConfig := &config.Config{}
 		gitConfig.User.Name = git.Username
 		gitConfig.User.Email = git.Email
 
 		receiver := &git.Instance{
-			Config:     gitConfig,
-			Repository: m1,
-			Worktree:   m2,
+			Config:                gitConfig,
+			Repository:            m1,
+			Worktree:              m2,
+			TagTemplate:           test.TagTemplate,
+			CommitMessageTemplate: test.CommitMessageTemplate,
+			TagMessageTemplate:    test.TagMessageTemplate,
 		}
 
-		err := receiver.Save(test.Files, test.Version, nil)
+		release := &git.Release{
+			Version:    test.Version,
+			OldVersion: test.OldVersion,
+			Files:      test.Files,
+			Changelog:  test.Changelog,
+		}
+		err := receiver.Save(release, nil, test.Tags...)
 		if test.ExpectedError != "" || err != nil {
 			a.EqualError(err, test.ExpectedError)
+		} else {
+			m1.AssertExpectations(t)
+			m2.AssertExpectations(t)
 		}
 	}
 }
@@ -148,8 +247,8 @@ func TestGit_Commit(t *testing.T) {
 			m2.On("Add", f).Return(nil, test.MockAddError).Once()
 		}
 
+		m2.On("Status").Return(gogit.Status{}, nil).Once()
 		m2.On("Commit", test.Version, &gogit.CommitOptions{
-			All:       true,
 			Author:    s,
 			Committer: s,
 		}).Return(plumbing.NewHash(test.MockCommitHash), test.MockCommitError).Once()
@@ -198,18 +297,22 @@ func TestGit_ConfigParser(t *testing.T) {
 	a.Equal("", missing)
 }
 
-func TestGit_ErrorGettingInstanceFromRepoFromConfigScoped(t *testing.T) {
+func TestGit_ErrorGettingInstanceFromRepoFromConfig(t *testing.T) {
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	meta := memfs.New()
+	assert.Nil(t, util.WriteFile(meta, "config", []byte("[user\n"), 0644))
 	m1 := new(mocks.Repository)
-	m1.On("ConfigScoped", config.GlobalScope).Return(nil, errors.New("test_mock_config_getter_error"))
-	_, err := git.GetInstanceFromRepo(m1)
-	assert.ErrorContains(t, err, fmt.Sprintf("%s: test_mock_config_getter_error", git.ErrStrRetrievingGlobalConfiguration))
+	_, err := git.GetInstanceFromRepo(m1, meta)
+	assert.ErrorContains(t, err, fmt.Sprintf(git.ErrStrFormattedReadingConfigFile, "config"))
 }
 
 func TestGit_ErrorGettingInstanceFromRepoFromWorktree(t *testing.T) {
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
 	m1 := new(mocks.Repository)
-	m1.On("ConfigScoped", config.GlobalScope).Return(config.NewConfig(), nil)
 	m1.On("Worktree").Return(nil, errors.New("test_mock_worktree_error"))
-	_, err := git.GetInstanceFromRepo(m1)
+	_, err := git.GetInstanceFromRepo(m1, memfs.New())
 	assert.ErrorContains(t, err, fmt.Sprintf("%s: test_mock_worktree_error", git.ErrStrRetrievingWorkTree))
 }
 
@@ -218,3 +321,238 @@ func TestGit_LoadConfig(t *testing.T) {
 	_, err := cp.LoadConfig(config.GlobalScope)
 	assert.Empty(t, err)
 }
+
+func TestGit_SaveTagKinds(t *testing.T) {
+	type test struct {
+		LightweightTags   bool
+		AliasTagTemplates []string
+		Sign              bool
+		Versions          []string
+		ExpectedTags      map[string]string
+		ExpectedAnnotated bool
+	}
+
+	suite := map[string]test{
+		"Annotated Alias Tags": {
+			AliasTagTemplates: []string{"v{{major}}", "v{{major}}.{{minor}}"},
+			Versions:          []string{"1.2.0", "1.3.0"},
+			ExpectedTags:      map[string]string{"v1.2.0": "1.2.0", "v1.2": "1.2.0", "v1.3.0": "1.3.0", "v1.3": "1.3.0", "v1": "1.3.0"},
+			ExpectedAnnotated: true,
+		},
+		"Lightweight Tags": {
+			LightweightTags:   true,
+			AliasTagTemplates: []string{"v{{major}}"},
+			Versions:          []string{"1.2.0", "1.2.1"},
+			ExpectedTags:      map[string]string{"v1.2.0": "1.2.0", "v1.2.1": "1.2.1", "v1": "1.2.1"},
+		},
+		"Signed Lightweight Tags": {
+			LightweightTags:   true,
+			AliasTagTemplates: []string{"v{{major}}"},
+			Sign:              true,
+			Versions:          []string{"1.2.0"},
+			ExpectedTags:      map[string]string{"v1.2.0": "1.2.0", "v1": "1.2.0"},
+			ExpectedAnnotated: true,
+		},
+		"Prerelease Without Alias Tags": {
+			AliasTagTemplates: []string{"v{{major}}"},
+			Versions:          []string{"1.2.0", "2.0.0-rc.1"},
+			ExpectedTags:      map[string]string{"v1.2.0": "1.2.0", "v2.0.0-rc.1": "2.0.0-rc.1", "v1": "1.2.0"},
+			ExpectedAnnotated: true,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		tr := newTestRepository(t)
+		tr.commit("initial", map[string]string{"VERSION": "1.1.0\n"})
+
+		i := tr.instance("")
+		i.Config = &config.Config{}
+		i.Config.User.Name = git.Username
+		i.Config.User.Email = git.Email
+		i.LightweightTags = test.LightweightTags
+		i.AliasTagTemplates = test.AliasTagTemplates
+
+		var entity *openpgp.Entity
+		if test.Sign {
+			var err error
+			entity, err = openpgp.NewEntity(git.Username, "", git.Email, nil)
+			a.Nil(err)
+		}
+
+		commits := make(map[string]plumbing.Hash)
+		for _, v := range test.Versions {
+			a.Nil(util.WriteFile(tr.fs, "VERSION", []byte(v+"\n"), 0644))
+			a.Nil(i.Save(&git.Release{Version: v, Files: []string{"VERSION"}}, entity))
+			head, err := tr.repo.Head()
+			a.Nil(err)
+			commits[v] = head.Hash()
+		}
+
+		tags := make(map[string]string)
+		refs, err := tr.repo.Tags()
+		a.Nil(err)
+		a.Nil(refs.ForEach(func(ref *plumbing.Reference) error {
+			hash := ref.Hash()
+			tag, err := tr.repo.TagObject(hash)
+			a.Equal(test.ExpectedAnnotated, err == nil, ref.Name().Short())
+			if err == nil {
+				hash = tag.Target
+				a.Equal(test.Sign, tag.PGPSignature != "")
+			}
+			for v, commit := range commits {
+				if commit == hash {
+					tags[ref.Name().Short()] = v
+				}
+			}
+			return nil
+		}))
+		a.Equal(test.ExpectedTags, tags)
+	}
+}
+
+func TestGit_CommitStaged(t *testing.T) {
+	type test struct {
+		IncludeStaged       bool
+		FailCommit          bool
+		ExpectedStagedFiles []string
+		ExpectedErrorStr    string
+	}
+
+	suite := map[string]test{
+		"Staged Changes Left Out": {
+			ExpectedStagedFiles: []string{"README.md", "notes"},
+		},
+		"Staged Changes Included": {
+			IncludeStaged:       true,
+			ExpectedStagedFiles: []string{},
+		},
+		"Staged Changes Kept When The Commit Fails": {
+			FailCommit:          true,
+			ExpectedStagedFiles: []string{"README.md", "notes"},
+			ExpectedErrorStr:    git.ErrStrCommittingChanges,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		tr := newTestRepository(t)
+		tr.commit("initial", map[string]string{"VERSION": "1.0.0\n", "README.md": "# app\n", "main.go": "package main\n"})
+		i := tr.instance("")
+		i.IncludeStaged = test.IncludeStaged
+
+		// a partially staged change, a staged new file, and a modification which is never staged
+		a.Nil(util.WriteFile(tr.fs, "README.md", []byte("# app, staged\n"), 0644))
+		_, err := i.Worktree.Add("README.md")
+		a.Nil(err)
+		a.Nil(util.WriteFile(tr.fs, "README.md", []byte("# app, staged\n\nunstaged\n"), 0644))
+		a.Nil(util.WriteFile(tr.fs, "notes", []byte("staged\n"), 0644))
+		_, err = i.Worktree.Add("notes")
+		a.Nil(err)
+		a.Nil(util.WriteFile(tr.fs, "notes", []byte("unstaged\n"), 0644))
+		a.Nil(util.WriteFile(tr.fs, "main.go", []byte("package app\n"), 0644))
+		a.Nil(util.WriteFile(tr.fs, "VERSION", []byte("1.1.0\n"), 0644))
+
+		// signing fails without a private key
+		var entity *openpgp.Entity
+		if test.FailCommit {
+			entity, err = openpgp.NewEntity(git.Username, "", git.Email, nil)
+			a.Nil(err)
+			entity.PrivateKey = nil
+		}
+
+		s := &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()}
+		hash, err := i.Commit([]string{"VERSION"}, "1.1.0", s, entity)
+
+		status, statusErr := i.Worktree.Status()
+		a.Nil(statusErr)
+		staged := make([]string, 0)
+		for file, s := range status {
+			if s.Staging != gogit.Unmodified && s.Staging != gogit.Untracked {
+				staged = append(staged, file)
+			}
+		}
+		sort.Strings(staged)
+		a.Equal(test.ExpectedStagedFiles, staged)
+		a.Equal(gogit.Modified, status.File("main.go").Worktree)
+		a.Equal(gogit.Modified, status.File("README.md").Worktree)
+
+		// the staged content of partially staged files is kept
+		idx, idxErr := tr.repo.Storer.Index()
+		a.Nil(idxErr)
+		for file, expected := range map[string]string{"README.md": "# app, staged\n", "notes": "staged\n"} {
+			entry, err := idx.Entry(file)
+			a.Nil(err)
+			blob, err := tr.repo.BlobObject(entry.Hash)
+			a.Nil(err)
+			reader, err := blob.Reader()
+			a.Nil(err)
+			content, err := io.ReadAll(reader)
+			a.Nil(err)
+			a.Equal(expected, string(content), file)
+		}
+
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			a.Equal(gogit.Unmodified, status.File("VERSION").Staging)
+			continue
+		}
+		a.Nil(err)
+
+		commit, err := tr.repo.CommitObject(hash)
+		a.Nil(err)
+		committed := map[string]string{
+			"VERSION":   "1.1.0\n",
+			"README.md": "# app\n",
+			"main.go":   "package main\n",
+		}
+		if test.IncludeStaged {
+			committed["README.md"] = "# app, staged\n"
+			committed["notes"] = "staged\n"
+		} else {
+			_, err = commit.File("notes")
+			a.ErrorIs(err, object.ErrFileNotFound)
+		}
+		for file, expected := range committed {
+			f, err := commit.File(file)
+			a.Nil(err)
+			content, err := f.Contents()
+			a.Nil(err)
+			a.Equal(expected, content, file)
+		}
+	}
+}
+
+func TestGit_Tag(t *testing.T) {
+	a := assert.New(t)
+	tr := newTestRepository(t)
+	released := tr.commit("1.0.0", map[string]string{"main.tf": "terraform {}\n"})
+	tr.tag("v1", released, false)
+	head := tr.commit("feat: outputs", map[string]string{"outputs.tf": "output \"id\" {}\n"})
+
+	i := tr.instance("")
+	i.AliasTagTemplates = []string{"v{{major}}"}
+
+	a.Nil(i.Tag(&git.Release{Version: "1.1.0", OldVersion: "1.0.0"}, nil))
+
+	after, err := tr.repo.Head()
+	a.Nil(err)
+	a.Equal(head, after.Hash())
+
+	for _, name := range []string{"v1.1.0", "v1"} {
+		ref, err := tr.repo.Tag(name)
+		a.Nil(err, name)
+		tag, err := tr.repo.TagObject(ref.Hash())
+		a.Nil(err, name)
+		a.Equal(head, tag.Target, name)
+		a.Equal("1.1.0\n", tag.Message, name)
+	}
+}
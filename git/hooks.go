@@ -0,0 +1,106 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/nidhhoggr/version-bump/console"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrWritingCommitMessage = "writing the commit message file"
+	ErrStrReadingCommitMessage = "reading the commit message file"
+
+	ErrStrFormattedRunningHook = "running the %v hook"
+)
+
+const (
+	PreCommitHook  = "pre-commit"
+	CommitMsgHook  = "commit-msg"
+	PostCommitHook = "post-commit"
+
+	// commitMessageFile is written to the git directory for the commit-msg hook, like git does
+	commitMessageFile = "COMMIT_EDITMSG"
+)
+
+// HookError is returned by Commit when the pre-commit or commit-msg hook fails, in which case no commit is created
+type HookError struct {
+	error
+}
+
+func (e *HookError) Unwrap() error {
+	return e.error
+}
+
+// hooksDir returns core.hooksPath, relative to the root of the working tree, or the hooks directory of the repository
+func (i *Instance) hooksDir() string {
+	hooksPath := ""
+	if i.Config != nil {
+		hooksPath = i.Config.Raw.Section("core").Option("hooksPath")
+	}
+	if hooksPath == "" {
+		return filepath.Join(commonDir(i.GitDir), "hooks")
+	}
+
+	if strings.HasPrefix(hooksPath, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			hooksPath = filepath.Join(home, hooksPath[2:])
+		}
+	}
+	if !filepath.IsAbs(hooksPath) {
+		hooksPath = filepath.Join(i.Dir, hooksPath)
+	}
+	return hooksPath
+}
+
+// runHook runs an executable hook from the root of the working tree, hooks which are missing or not executable are skipped
+func (i *Instance) runHook(name string, args ...string) error {
+	hook := filepath.Join(i.hooksDir(), name)
+	info, err := os.Stat(hook)
+	if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+		return nil
+	}
+
+	cmd := exec.Command(hook, args...)
+	cmd.Dir = i.Dir
+	cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+filepath.Join(i.GitDir, "index"), "GIT_EDITOR=:")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, ErrStrFormattedRunningHook, name)
+	}
+	return nil
+}
+
+// runCommitHooks runs the pre-commit hook, then the commit-msg hook with the message written to a file it may edit.
+// It returns the message read back from the file.
+func (i *Instance) runCommitHooks(message string) (string, error) {
+	if err := i.runHook(PreCommitHook); err != nil {
+		return "", &HookError{err}
+	}
+
+	file := filepath.Join(i.GitDir, commitMessageFile)
+	if err := os.WriteFile(file, []byte(message), 0644); err != nil {
+		return "", errors.Wrap(err, ErrStrWritingCommitMessage)
+	}
+	if err := i.runHook(CommitMsgHook, file); err != nil {
+		return "", &HookError{err}
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrap(err, ErrStrReadingCommitMessage)
+	}
+
+	return string(content), nil
+}
+
+// runPostCommitHook runs the post-commit hook, whose failure does not affect the commit, as with git
+func (i *Instance) runPostCommitHook() {
+	if err := i.runHook(PostCommitHook); err != nil {
+		console.Error(err)
+	}
+}
@@ -0,0 +1,166 @@
+package git_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGit_CommitHooks(t *testing.T) {
+	type test struct {
+		RunHooks bool
+		// Hooks are written to HooksDir, relative to the root of the working tree, .git/hooks by default
+		Hooks            map[string]string
+		HooksDir         string
+		NotExecutable    bool
+		ExpectedMessage  string
+		ExpectedHookFail bool
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Disabled": {
+			Hooks:           map[string]string{git.PreCommitHook: "exit 1"},
+			ExpectedMessage: "release: v1.1.0",
+		},
+		"Pre-Commit Fails": {
+			RunHooks:         true,
+			Hooks:            map[string]string{git.PreCommitHook: "exit 1"},
+			ExpectedHookFail: true,
+			ExpectedErrorStr: "running the pre-commit hook: exit status 1",
+		},
+		"Pre-Commit Sees The Staged Files": {
+			RunHooks:        true,
+			Hooks:           map[string]string{git.PreCommitHook: "git diff --cached --name-only | grep -qx VERSION"},
+			ExpectedMessage: "release: v1.1.0",
+		},
+		"Commit-Msg Edits The Message": {
+			RunHooks:        true,
+			Hooks:           map[string]string{git.CommitMsgHook: "printf '\\n\\nSigned-off-by: Jane Doe <jane@example.com>' >> \"$1\""},
+			ExpectedMessage: "release: v1.1.0\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		"Commit-Msg Fails": {
+			RunHooks:         true,
+			Hooks:            map[string]string{git.CommitMsgHook: "grep -q Signed-off-by \"$1\""},
+			ExpectedHookFail: true,
+			ExpectedErrorStr: "running the commit-msg hook: exit status 1",
+		},
+		"Post-Commit Failure Is Ignored": {
+			RunHooks:        true,
+			Hooks:           map[string]string{git.PostCommitHook: "exit 1"},
+			ExpectedMessage: "release: v1.1.0",
+		},
+		"Hooks Path": {
+			RunHooks:         true,
+			Hooks:            map[string]string{git.PreCommitHook: "exit 1"},
+			HooksDir:         ".githooks",
+			ExpectedHookFail: true,
+			ExpectedErrorStr: "running the pre-commit hook: exit status 1",
+		},
+		"Not Executable": {
+			RunHooks:        true,
+			Hooks:           map[string]string{git.PreCommitHook: "exit 1"},
+			NotExecutable:   true,
+			ExpectedMessage: "release: v1.1.0",
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+		t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+
+		root := t.TempDir()
+		repo, err := gogit.PlainInit(root, false)
+		a.Nil(err)
+		wt, err := repo.Worktree()
+		a.Nil(err)
+		a.Nil(os.WriteFile(filepath.Join(root, "VERSION"), []byte("1.0.0\n"), 0644))
+		a.Nil(os.WriteFile(filepath.Join(root, "README.md"), []byte("# app\n"), 0644))
+		_, err = wt.Add("VERSION")
+		a.Nil(err)
+		_, err = wt.Add("README.md")
+		a.Nil(err)
+		initial, err := wt.Commit("initial", &gogit.CommitOptions{
+			Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+		})
+		a.Nil(err)
+
+		hooksDir := filepath.Join(root, ".git", "hooks")
+		if test.HooksDir != "" {
+			hooksDir = filepath.Join(root, test.HooksDir)
+			cfg, err := repo.Config()
+			a.Nil(err)
+			cfg.Raw.Section("core").SetOption("hooksPath", test.HooksDir)
+			a.Nil(repo.SetConfig(cfg))
+		}
+		a.Nil(os.MkdirAll(hooksDir, 0755))
+		mode := os.FileMode(0755)
+		if test.NotExecutable {
+			mode = 0644
+		}
+		for hook, script := range test.Hooks {
+			a.Nil(os.WriteFile(filepath.Join(hooksDir, hook), []byte("#!/bin/sh\n"+script+"\n"), mode))
+		}
+
+		i, err := git.New(osfs.New(filepath.Join(root, ".git")), osfs.New(root))
+		a.Nil(err)
+		i.RunHooks = test.RunHooks
+
+		// a change staged beforehand is left out of the release commit, and staged again whether the hooks pass or not
+		a.Nil(os.WriteFile(filepath.Join(root, "README.md"), []byte("# app\n\nstaged\n"), 0644))
+		_, err = wt.Add("README.md")
+		a.Nil(err)
+		a.Nil(os.WriteFile(filepath.Join(root, "VERSION"), []byte("1.1.0\n"), 0644))
+
+		_, err = i.Commit([]string{"VERSION"}, "release: v1.1.0", &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()}, nil)
+
+		status, statusErr := wt.Status()
+		a.Nil(statusErr)
+		a.Equal(gogit.Modified, status.File("README.md").Staging)
+
+		head, headErr := repo.Head()
+		a.Nil(headErr)
+
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			var hookErr *git.HookError
+			a.Equal(test.ExpectedHookFail, errors.As(err, &hookErr))
+			a.Equal(initial, head.Hash())
+			a.Equal(gogit.Unmodified, status.File("VERSION").Staging)
+			a.Equal(gogit.Modified, status.File("VERSION").Worktree)
+			continue
+		}
+		a.Nil(err)
+
+		commit, err := repo.CommitObject(head.Hash())
+		a.Nil(err)
+		a.Equal(test.ExpectedMessage, commit.Message)
+		a.Equal([]string{"VERSION"}, changedFiles(a, commit))
+	}
+}
+
+// changedFiles returns the files changed by commit since its parent
+func changedFiles(a *assert.Assertions, commit *object.Commit) []string {
+	parent, err := commit.Parent(0)
+	a.Nil(err)
+	patch, err := parent.Patch(commit)
+	a.Nil(err)
+	files := make([]string, 0)
+	for _, stat := range patch.Stats() {
+		files = append(files, stat.Name)
+	}
+	return files
+}
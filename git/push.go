@@ -0,0 +1,143 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrPushing      = "pushing the release"
+	ErrStrDetachedHead = "HEAD is detached, checkout a branch to push the release"
+
+	ErrStrFormattedReadingRemote    = "reading remote %v"
+	ErrStrFormattedRemoteWithoutURL = "remote %v has no URL"
+	ErrStrFormattedAuthenticating   = "authenticating to remote %v"
+	ErrStrFormattedNonFastForward   = "the branch %v of remote %v has commits which are missing locally, pull them and push again"
+)
+
+const (
+	// DefaultRemote is the remote releases are pushed to when no remote is configured
+	DefaultRemote = "origin"
+	// DefaultTokenEnv is the environment variable holding the token of http remotes when none is configured
+	DefaultTokenEnv = "GIT_TOKEN"
+	// DefaultTokenUsername is sent along with the token of http remotes when no username is configured
+	DefaultTokenUsername = "git"
+)
+
+// PushConfig used to parse the [push] section of the .bump toml file
+type PushConfig struct {
+	// Remote is the name of the remote to push to, default origin
+	Remote string
+	// TokenEnv is the environment variable holding the token of http remotes, default GIT_TOKEN
+	TokenEnv string `toml:"token_env"`
+	// Username is sent along with the token of http remotes, default git
+	Username string
+}
+
+// Push pushes the current branch, the tag of release and any additional tags to the configured remote.
+// Alias tags are force pushed as they move to each release.
+func (i *Instance) Push(cfg *PushConfig, release *Release, tags ...Tag) error {
+	head, err := i.Repository.Head()
+	if err != nil {
+		return errors.Wrap(err, ErrStrReadingHead)
+	} else if !head.Name().IsBranch() {
+		return errors.New(ErrStrDetachedHead)
+	}
+
+	refSpecs := []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name()))}
+	tags = append([]Tag{{Name: i.TagName(release.Version)}}, tags...)
+	for _, tag := range tags {
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("refs/tags/%[1]s:refs/tags/%[1]s", tag.Name)))
+	}
+	aliases, err := i.AliasTags(release)
+	if err != nil {
+		return errors.Wrap(err, ErrStrPushing)
+	}
+	for _, alias := range aliases {
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("+refs/tags/%[1]s:refs/tags/%[1]s", alias)))
+	}
+
+	return i.push(cfg, head.Name().Short(), refSpecs)
+}
+
+// PushBranch pushes the branch name to the configured remote
+func (i *Instance) PushBranch(cfg *PushConfig, name string) error {
+	refName := plumbing.NewBranchReferenceName(name)
+	return i.push(cfg, name, []config.RefSpec{config.RefSpec(fmt.Sprintf("%s:%s", refName, refName))})
+}
+
+// push pushes refSpecs to the configured remote, where branch is the branch they update
+func (i *Instance) push(cfg *PushConfig, branch string, refSpecs []config.RefSpec) error {
+	remoteName := cfg.RemoteName()
+
+	remote, err := i.Repository.Remote(remoteName)
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedReadingRemote, remoteName)
+	} else if len(remote.Config().URLs) == 0 {
+		return fmt.Errorf(ErrStrFormattedRemoteWithoutURL, remoteName)
+	}
+
+	auth, err := cfg.Auth(remote.Config().URLs[0])
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedAuthenticating, remoteName)
+	}
+
+	err = i.Repository.Push(&git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   refSpecs,
+		Auth:       auth,
+	})
+	if err == nil || errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	} else if strings.Contains(err.Error(), "non-fast-forward") {
+		return fmt.Errorf(ErrStrFormattedNonFastForward, branch, remoteName)
+	}
+
+	return errors.Wrap(err, ErrStrPushing)
+}
+
+// RemoteName returns the name of the configured remote, or DefaultRemote
+func (cfg *PushConfig) RemoteName() string {
+	if cfg.Remote == "" {
+		return DefaultRemote
+	}
+	return cfg.Remote
+}
+
+// Auth returns the ssh agent for ssh remotes, a token read from the environment for http remotes, or nil
+func (cfg *PushConfig) Auth(url string) (transport.AuthMethod, error) {
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return nil, err
+	}
+
+	switch endpoint.Protocol {
+	case "ssh":
+		return ssh.NewSSHAgentAuth(endpoint.User)
+	case "http", "https":
+		tokenEnv := cfg.TokenEnv
+		if tokenEnv == "" {
+			tokenEnv = DefaultTokenEnv
+		}
+		token := os.Getenv(tokenEnv)
+		if token == "" {
+			return nil, nil
+		}
+		username := cfg.Username
+		if username == "" {
+			username = DefaultTokenUsername
+		}
+		return &http.BasicAuth{Username: username, Password: token}, nil
+	}
+
+	return nil, nil
+}
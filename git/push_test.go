@@ -0,0 +1,197 @@
+package git_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/stretchr/testify/assert"
+)
+
+// newClone initializes a repository on disk whose origin remote is a new bare repository, also returned
+func newClone(t *testing.T) (*git.Instance, *gogit.Repository) {
+	a := assert.New(t)
+
+	bareDir := t.TempDir()
+	bare, err := gogit.PlainInit(bareDir, true)
+	a.Nil(err)
+
+	repo, err := gogit.PlainInit(t.TempDir(), false)
+	a.Nil(err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemote, URLs: []string{bareDir}})
+	a.Nil(err)
+
+	wt, err := repo.Worktree()
+	a.Nil(err)
+	gitConfig := &config.Config{}
+	gitConfig.User.Name = git.Username
+	gitConfig.User.Email = git.Email
+
+	return &git.Instance{Repository: repo, Worktree: wt, Config: gitConfig}, bare
+}
+
+func release(t *testing.T, i *git.Instance, version string) *git.Release {
+	wt := i.Worktree.(*gogit.Worktree)
+	assert.Nil(t, util.WriteFile(wt.Filesystem, "VERSION", []byte(version+"\n"), 0644))
+	r := &git.Release{Version: version, Files: []string{"VERSION"}}
+	assert.Nil(t, i.Save(r, nil))
+	return r
+}
+
+func TestGit_Push(t *testing.T) {
+	a := assert.New(t)
+
+	i, bare := newClone(t)
+	i.AliasTagTemplates = []string{"v{{major}}"}
+	cfg := &git.PushConfig{}
+
+	r := release(t, i, "1.2.0")
+	a.Nil(i.Push(cfg, r))
+
+	// pushing again is a no-op
+	a.Nil(i.Push(cfg, r))
+
+	r = release(t, i, "1.3.0")
+	a.Nil(i.Push(cfg, r))
+
+	head, err := i.Repository.Head()
+	a.Nil(err)
+	remoteHead, err := bare.Reference(head.Name(), true)
+	a.Nil(err)
+	a.Equal(head.Hash(), remoteHead.Hash())
+
+	for name, version := range map[string]string{"v1.2.0": "1.2.0", "v1.3.0": "1.3.0", "v1": "1.3.0"} {
+		ref, err := bare.Tag(name)
+		a.Nil(err, name)
+		tag, err := bare.TagObject(ref.Hash())
+		a.Nil(err, name)
+		a.Equal(version+"\n", tag.Message, name)
+	}
+}
+
+func TestGit_PushErrors(t *testing.T) {
+	type test struct {
+		Setup            func(t *testing.T, i *git.Instance, bare *gogit.Repository)
+		Config           git.PushConfig
+		ExpectedErrorStr string
+	}
+
+	suite := map[string]test{
+		"Non Fast Forward": {
+			Setup: func(t *testing.T, i *git.Instance, bare *gogit.Repository) {
+				// another commit reached the remote after the previous release
+				head, err := i.Repository.Head()
+				assert.Nil(t, err)
+				commit, err := i.Repository.CommitObject(head.Hash())
+				assert.Nil(t, err)
+				other := &object.Commit{
+					Author:       object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+					Committer:    object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+					Message:      "fix: concurrent change",
+					TreeHash:     commit.TreeHash,
+					ParentHashes: []plumbing.Hash{commit.Hash},
+				}
+				obj := bare.Storer.NewEncodedObject()
+				assert.Nil(t, other.Encode(obj))
+				hash, err := bare.Storer.SetEncodedObject(obj)
+				assert.Nil(t, err)
+				assert.Nil(t, bare.Storer.SetReference(plumbing.NewHashReference(head.Name(), hash)))
+			},
+			ExpectedErrorStr: fmt.Sprintf(git.ErrStrFormattedNonFastForward, "master", git.DefaultRemote),
+		},
+		"Detached Head": {
+			Setup: func(t *testing.T, i *git.Instance, bare *gogit.Repository) {
+				head, err := i.Repository.Head()
+				assert.Nil(t, err)
+				assert.Nil(t, i.Worktree.(*gogit.Worktree).Checkout(&gogit.CheckoutOptions{Hash: head.Hash()}))
+			},
+			ExpectedErrorStr: git.ErrStrDetachedHead,
+		},
+		"Unknown Remote": {
+			Config:           git.PushConfig{Remote: "upstream"},
+			ExpectedErrorStr: fmt.Sprintf("%s: %s", fmt.Sprintf(git.ErrStrFormattedReadingRemote, "upstream"), gogit.ErrRemoteNotFound),
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		i, bare := newClone(t)
+		a.Nil(i.Push(&git.PushConfig{}, release(t, i, "1.2.0")))
+		if test.Setup != nil {
+			test.Setup(t, i, bare)
+		}
+
+		r := release(t, i, "1.3.0")
+		a.EqualError(i.Push(&test.Config, r), test.ExpectedErrorStr)
+	}
+}
+
+func TestGit_PushAuth(t *testing.T) {
+	type test struct {
+		Config       git.PushConfig
+		URL          string
+		Env          map[string]string
+		ExpectedAuth interface{}
+		ExpectError  bool
+	}
+
+	suite := map[string]test{
+		"Local": {
+			URL: "/srv/git/app.git",
+		},
+		"HTTP Without Token": {
+			URL: "https://github.com/acme/app.git",
+		},
+		"HTTP Token": {
+			URL:          "https://github.com/acme/app.git",
+			Env:          map[string]string{git.DefaultTokenEnv: "secret"},
+			ExpectedAuth: &http.BasicAuth{Username: git.DefaultTokenUsername, Password: "secret"},
+		},
+		"HTTP Configured Token": {
+			Config:       git.PushConfig{TokenEnv: "GITHUB_TOKEN", Username: "x-access-token"},
+			URL:          "https://github.com/acme/app.git",
+			Env:          map[string]string{"GITHUB_TOKEN": "secret", git.DefaultTokenEnv: "other"},
+			ExpectedAuth: &http.BasicAuth{Username: "x-access-token", Password: "secret"},
+		},
+		"SSH Without Agent": {
+			URL:         "git@github.com:acme/app.git",
+			Env:         map[string]string{"SSH_AUTH_SOCK": ""},
+			ExpectError: true,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		t.Setenv(git.DefaultTokenEnv, "")
+		for k, v := range test.Env {
+			t.Setenv(k, v)
+		}
+
+		auth, err := test.Config.Auth(test.URL)
+		if test.ExpectError {
+			a.NotNil(err)
+			continue
+		}
+		a.Nil(err)
+		if test.ExpectedAuth == nil {
+			a.Nil(auth)
+		} else {
+			a.Equal(test.ExpectedAuth, auth)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package git
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/pkg/errors"
+	"golang.org/x/mod/semver"
+)
+
+var (
+	ErrStrReadingTags          = "reading tags"
+	ErrStrReadingHead          = "reading HEAD"
+	ErrStrWalkingCommits       = "walking commit history"
+	ErrStrFormattedReadingTree = "reading tree of commit %v"
+)
+
+// ReleaseTag is a tag whose name matches the tag template of an Instance
+type ReleaseTag struct {
+	Name string
+	// Version is the version of the tag without the tag template, e.g. 1.2.3 for tools/cli/v1.2.3
+	Version string
+	Commit  *object.Commit
+}
+
+// VersionFromTag returns the version of tagName when it matches tagTemplate.
+// The version must appear once in the rendered tag template, e.g. v{{version}} or {{.Version}}-stable.
+func VersionFromTag(tagTemplate string, tagName string) (string, bool) {
+	if tagTemplate == "" {
+		tagTemplate = DefaultTagTemplate
+	}
+	rendered, err := Render(tagTemplate, &Release{Version: versionPlaceholder})
+	if err != nil || strings.Count(rendered, versionPlaceholder) != 1 {
+		return "", false
+	}
+	prefix, suffix, found := strings.Cut(rendered, versionPlaceholder)
+	if !found || !strings.HasPrefix(tagName, prefix) || !strings.HasSuffix(tagName, suffix) || len(tagName) < len(prefix)+len(suffix) {
+		return "", false
+	}
+	version := tagName[len(prefix) : len(tagName)-len(suffix)]
+	// shorthands such as v1.2 are valid for semver but are not release versions
+	canonical, _, _ := strings.Cut("v"+version, "+")
+	if !semver.IsValid("v"+version) || semver.Canonical("v"+version) != canonical {
+		return "", false
+	}
+	return version, true
+}
+
+// ReleaseTags returns the tags matching the tag template, from the highest to the lowest version
+func (i *Instance) ReleaseTags() ([]ReleaseTag, error) {
+	refs, err := i.Repository.Tags()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingTags)
+	}
+
+	tags := make([]ReleaseTag, 0)
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		version, ok := VersionFromTag(i.TagTemplate, ref.Name().Short())
+		if !ok {
+			return nil
+		}
+		commit, err := i.tagCommit(ref)
+		if err != nil {
+			return err
+		}
+		tags = append(tags, ReleaseTag{
+			Name:    ref.Name().Short(),
+			Version: version,
+			Commit:  commit,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingTags)
+	}
+
+	sort.SliceStable(tags, func(a, b int) bool {
+		return semver.Compare("v"+tags[a].Version, "v"+tags[b].Version) > 0
+	})
+
+	return tags, nil
+}
+
+// LatestReachableTag returns the release tag with the highest version among those reachable from HEAD, or nil when there are none
+func (i *Instance) LatestReachableTag() (*ReleaseTag, error) {
+	tags, err := i.ReleaseTags()
+	if err != nil {
+		return nil, err
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	ancestors, err := i.headAncestors()
+	if err != nil {
+		return nil, err
+	}
+
+	for j := range tags {
+		if ancestors[tags[j].Commit.Hash] {
+			return &tags[j], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// CommitsSince returns the commits reachable from HEAD but not from the commit of tag, newest first.
+// Every commit since the first one is returned when tag is nil. When dirs are given, only commits changing files within them are returned.
+func (i *Instance) CommitsSince(tag *ReleaseTag, dirs []string) ([]*object.Commit, error) {
+	released := make(map[plumbing.Hash]bool)
+	if tag != nil {
+		commits, err := i.Repository.Log(&git.LogOptions{From: tag.Commit.Hash})
+		if err != nil {
+			return nil, errors.Wrap(err, ErrStrWalkingCommits)
+		}
+		err = commits.ForEach(func(c *object.Commit) error {
+			released[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, ErrStrWalkingCommits)
+		}
+	}
+
+	head, err := i.Repository.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingHead)
+	}
+
+	opts := &git.LogOptions{From: head.Hash()}
+	if len(dirs) > 0 && !(len(dirs) == 1 && path.Clean(dirs[0]) == ".") {
+		opts.PathFilter = func(name string) bool {
+			for _, dir := range dirs {
+				dir = path.Clean(dir)
+				if dir == "." || name == dir || strings.HasPrefix(name, dir+"/") {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	commits, err := i.Repository.Log(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrWalkingCommits)
+	}
+
+	since := make([]*object.Commit, 0)
+	err = commits.ForEach(func(c *object.Commit) error {
+		if !released[c.Hash] {
+			since = append(since, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrWalkingCommits)
+	}
+
+	return since, nil
+}
+
+// headAncestors returns the hashes of HEAD and every commit reachable from it
+func (i *Instance) headAncestors() (map[plumbing.Hash]bool, error) {
+	head, err := i.Repository.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingHead)
+	}
+
+	commits, err := i.Repository.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrWalkingCommits)
+	}
+
+	ancestors := make(map[plumbing.Hash]bool)
+	err = commits.ForEach(func(c *object.Commit) error {
+		ancestors[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrWalkingCommits)
+	}
+
+	return ancestors, nil
+}
+
+// tagCommit resolves the commit of an annotated or lightweight tag
+func (i *Instance) tagCommit(ref *plumbing.Reference) (*object.Commit, error) {
+	tag, err := i.Repository.TagObject(ref.Hash())
+	if err == nil {
+		return tag.Commit()
+	} else if err != plumbing.ErrObjectNotFound {
+		return nil, err
+	}
+	return i.Repository.CommitObject(ref.Hash())
+}
+
+// ReadFiles returns the content of the files within dir of the tree of commit accepted by match, keyed by their path relative to dir
+func ReadFiles(commit *object.Commit, dir string, match func(string) bool) (map[string][]byte, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, errors.Wrapf(err, ErrStrFormattedReadingTree, commit.Hash)
+	}
+
+	dir = path.Clean(dir)
+	files := make(map[string][]byte)
+	err = tree.Files().ForEach(func(f *object.File) error {
+		name := f.Name
+		if dir != "." {
+			if !strings.HasPrefix(name, dir+"/") {
+				return nil
+			}
+			name = strings.TrimPrefix(name, dir+"/")
+		}
+		if !match(name) {
+			return nil
+		}
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+		files[name] = []byte(content)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, ErrStrFormattedReadingTree, commit.Hash)
+	}
+
+	return files, nil
+}
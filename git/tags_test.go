@@ -0,0 +1,185 @@
+package git_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRepository struct {
+	t    *testing.T
+	repo *gogit.Repository
+	fs   billy.Filesystem
+}
+
+func newTestRepository(t *testing.T) *testRepository {
+	fs := memfs.New()
+	repo, err := gogit.Init(memory.NewStorage(), fs)
+	assert.Nil(t, err)
+	return &testRepository{t: t, repo: repo, fs: fs}
+}
+
+// commit writes files and commits them with message
+func (tr *testRepository) commit(message string, files map[string]string) plumbing.Hash {
+	wt, err := tr.repo.Worktree()
+	assert.Nil(tr.t, err)
+	for name, content := range files {
+		assert.Nil(tr.t, util.WriteFile(tr.fs, name, []byte(content), 0644))
+		_, err = wt.Add(name)
+		assert.Nil(tr.t, err)
+	}
+	hash, err := wt.Commit(message, &gogit.CommitOptions{
+		Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+	})
+	assert.Nil(tr.t, err)
+	return hash
+}
+
+func (tr *testRepository) tag(name string, hash plumbing.Hash, annotated bool) {
+	var opts *gogit.CreateTagOptions
+	if annotated {
+		opts = &gogit.CreateTagOptions{
+			Tagger:  &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+			Message: name,
+		}
+	}
+	_, err := tr.repo.CreateTag(name, hash, opts)
+	assert.Nil(tr.t, err)
+}
+
+func (tr *testRepository) instance(tagTemplate string) *git.Instance {
+	wt, err := tr.repo.Worktree()
+	assert.Nil(tr.t, err)
+	gitConfig := &config.Config{}
+	gitConfig.User.Name = git.Username
+	gitConfig.User.Email = git.Email
+	return &git.Instance{
+		Repository:  tr.repo,
+		Worktree:    wt,
+		Config:      gitConfig,
+		TagTemplate: tagTemplate,
+		References:  tr.repo.Storer,
+		Index:       tr.repo.Storer,
+	}
+}
+
+func TestGit_VersionFromTag(t *testing.T) {
+	type test struct {
+		TagTemplate     string
+		TagName         string
+		ExpectedVersion string
+		ExpectedOk      bool
+	}
+
+	suite := map[string]test{
+		"Default Template":        {TagName: "v1.2.3", ExpectedVersion: "1.2.3", ExpectedOk: true},
+		"Prerelease":              {TagName: "v1.2.3-rc.1", ExpectedVersion: "1.2.3-rc.1", ExpectedOk: true},
+		"Directory Prefix":        {TagTemplate: "tools/cli/v{{version}}", TagName: "tools/cli/v1.4.0", ExpectedVersion: "1.4.0", ExpectedOk: true},
+		"Unprefixed":              {TagTemplate: "{{version}}", TagName: "1.4.0", ExpectedVersion: "1.4.0", ExpectedOk: true},
+		"Other Package":           {TagTemplate: "api-v{{version}}", TagName: "web-v1.4.0", ExpectedOk: false},
+		"Not A Version":           {TagName: "vnext", ExpectedOk: false},
+		"Partial Version":         {TagName: "v1.2", ExpectedOk: false},
+		"Unprefixed With V":       {TagTemplate: "{{version}}", TagName: "v1.4.0", ExpectedOk: false},
+		"Template Without Holder": {TagTemplate: "latest", TagName: "latest", ExpectedOk: false},
+		"Go Template":             {TagTemplate: "release-{{.Version}}-stable", TagName: "release-1.4.0-stable", ExpectedVersion: "1.4.0", ExpectedOk: true},
+		"Version Twice":           {TagTemplate: "{{version}}/v{{.Version}}", TagName: "1.4.0/v1.4.0", ExpectedOk: false},
+		"Invalid Template":        {TagTemplate: "v{{version", TagName: "v1.4.0", ExpectedOk: false},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		v, ok := git.VersionFromTag(test.TagTemplate, test.TagName)
+		a := assert.New(t)
+		a.Equal(test.ExpectedVersion, v)
+		a.Equal(test.ExpectedOk, ok)
+	}
+}
+
+func TestGit_LatestReachableTag(t *testing.T) {
+	a := assert.New(t)
+	tr := newTestRepository(t)
+
+	first := tr.commit("1.0.0", map[string]string{"VERSION": "1.0.0\n"})
+	tr.tag("v1.0.0", first, true)
+	second := tr.commit("1.1.0", map[string]string{"VERSION": "1.1.0\n"})
+	tr.tag("v1.1.0", second, false)
+	tr.tag("api-v9.0.0", second, true)
+	tr.commit("unreleased", map[string]string{"main.go": "package main\n"})
+
+	i := tr.instance("")
+
+	tags, err := i.ReleaseTags()
+	a.Nil(err)
+	a.Len(tags, 2)
+	a.Equal("v1.1.0", tags[0].Name)
+	a.Equal("1.0.0", tags[1].Version)
+	a.Equal(first, tags[1].Commit.Hash)
+
+	tag, err := i.LatestReachableTag()
+	a.Nil(err)
+	a.Equal("v1.1.0", tag.Name)
+	a.Equal(second, tag.Commit.Hash)
+
+	// a higher tag on a commit which is not an ancestor of HEAD is ignored
+	wt, err := tr.repo.Worktree()
+	a.Nil(err)
+	a.Nil(wt.Checkout(&gogit.CheckoutOptions{Hash: first, Branch: plumbing.NewBranchReferenceName("maintenance"), Create: true}))
+	tag, err = i.LatestReachableTag()
+	a.Nil(err)
+	a.Equal("v1.0.0", tag.Name)
+
+	tag, err = tr.instance("web-v{{version}}").LatestReachableTag()
+	a.Nil(err)
+	a.Nil(tag)
+
+	files, err := git.ReadFiles(tags[0].Commit, ".", func(name string) bool { return name == "VERSION" })
+	a.Nil(err)
+	a.Equal(map[string][]byte{"VERSION": []byte("1.1.0\n")}, files)
+}
+
+func TestGit_CommitsSince(t *testing.T) {
+	a := assert.New(t)
+	tr := newTestRepository(t)
+
+	released := tr.commit("feat: first", map[string]string{"api/VERSION": "1.0.0\n"})
+	tr.tag("v1.0.0", released, true)
+	apiFix := tr.commit("fix(api): crash", map[string]string{"api/main.go": "package main\n"})
+	webFeat := tr.commit("feat(web): login", map[string]string{"web/main.go": "package main\n"})
+
+	i := tr.instance("")
+	tag, err := i.LatestReachableTag()
+	a.Nil(err)
+
+	hashes := func(commits []*object.Commit) []plumbing.Hash {
+		res := make([]plumbing.Hash, 0)
+		for _, c := range commits {
+			res = append(res, c.Hash)
+		}
+		return res
+	}
+
+	commits, err := i.CommitsSince(tag, nil)
+	a.Nil(err)
+	a.Equal([]plumbing.Hash{webFeat, apiFix}, hashes(commits))
+
+	commits, err = i.CommitsSince(tag, []string{"api"})
+	a.Nil(err)
+	a.Equal([]plumbing.Hash{apiFix}, hashes(commits))
+
+	commits, err = i.CommitsSince(nil, []string{"."})
+	a.Nil(err)
+	a.Equal([]plumbing.Hash{webFeat, apiFix, released}, hashes(commits))
+}
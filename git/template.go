@@ -0,0 +1,112 @@
+package git
+
+import (
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrFormattedRenderingTemplate = "rendering template %q"
+)
+
+const (
+	// DefaultCommitMessageTemplate is the message of release commits when no CommitMessageTemplate is set
+	DefaultCommitMessageTemplate string = "{{version}}"
+	// DefaultTagMessageTemplate is the message of release tags when no TagMessageTemplate is set
+	DefaultTagMessageTemplate string = "{{version}}"
+
+	// versionPlaceholder stands for the version when matching tag names against a tag template
+	versionPlaceholder = "\x00"
+)
+
+// Config used to parse the [git] section of the .bump toml file
+type Config struct {
+	CommitMessage string `toml:"commit_message"`
+	TagName       string `toml:"tag_name"`
+	TagMessage    string `toml:"tag_message"`
+	// LightweightTags creates lightweight instead of annotated tags, unless they are signed
+	LightweightTags bool `toml:"lightweight_tags"`
+	// AliasTags are moved to each release, e.g. v{{major}} and v{{major}}.{{minor}}
+	AliasTags []string `toml:"alias_tags"`
+	// IncludeStaged commits the changes staged before the release along with the bumped files
+	IncludeStaged bool `toml:"include_staged"`
+	// TagOnly reads the version from the highest reachable tag and only tags HEAD, for repositories without a version in any file
+	TagOnly bool `toml:"tag_only"`
+	// RunHooks runs the pre-commit, commit-msg and post-commit hooks of the repository around release commits
+	RunHooks bool `toml:"run_hooks"`
+}
+
+// Release is the data of the commit message, tag name and tag message templates, e.g. release: v{{.Version}} [skip ci].
+// The {{version}} function is an alias of {{.Version}}, and {{major}}, {{minor}} and {{patch}} return its components.
+type Release struct {
+	Version    string
+	OldVersion string
+	// Files are the files changed by the release commit
+	Files []string
+	Date  time.Time
+	// Changelog is the rendered changelog section of the release, if any
+	Changelog string
+	// Tag is the name of the release tag, empty while rendering the tag name itself
+	Tag string
+	// Author is the git user making the release, e.g. "Jane Doe <jane@example.com>" for Signed-off-by trailers
+	Author string
+	// Branch is the sanitized current branch, only set while rendering the identifier of branch prereleases
+	Branch string
+}
+
+// Render executes the Go template text with the data of release
+func Render(text string, release *Release) (string, error) {
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"version": func() string { return release.Version },
+		"major":   func() string { return versionComponent(release.Version, 0) },
+		"minor":   func() string { return versionComponent(release.Version, 1) },
+		"patch":   func() string { return versionComponent(release.Version, 2) },
+	}).Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, ErrStrFormattedRenderingTemplate, text)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, release); err != nil {
+		return "", errors.Wrapf(err, ErrStrFormattedRenderingTemplate, text)
+	}
+
+	return sb.String(), nil
+}
+
+// CommitMessage returns the message of the release commit
+func (i *Instance) CommitMessage(release *Release) (string, error) {
+	return renderOrDefault(i.CommitMessageTemplate, DefaultCommitMessageTemplate, release)
+}
+
+// TagMessage returns the message of the release tag, or the version when the template renders nothing
+func (i *Instance) TagMessage(release *Release) (string, error) {
+	message, err := renderOrDefault(i.TagMessageTemplate, DefaultTagMessageTemplate, release)
+	if err != nil {
+		return "", err
+	} else if strings.TrimSpace(message) == "" {
+		return release.Version, nil
+	}
+	return message, nil
+}
+
+// versionComponent returns the major (0), minor (1) or patch (2) number of version
+func versionComponent(version string, index int) string {
+	core, _, _ := strings.Cut(version, "+")
+	core, _, _ = strings.Cut(core, "-")
+	components := strings.Split(core, ".")
+	if index >= len(components) {
+		return ""
+	}
+	return components[index]
+}
+
+func renderOrDefault(text string, defaultText string, release *Release) (string, error) {
+	if text == "" {
+		text = defaultText
+	}
+	return Render(text, release)
+}
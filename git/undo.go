@@ -0,0 +1,286 @@
+package git
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrStrUndoing                = "undoing the release"
+	ErrStrUndoDetachedHead       = "HEAD is detached, checkout the branch of the release to undo it"
+	ErrStrUndoUncommittedChanges = "the working tree has uncommitted changes, commit or stash them before undoing the release"
+
+	ErrStrFormattedHeadNotTagged      = "HEAD %v is not tagged with a release"
+	ErrStrFormattedNotAReleaseCommit  = "the message of HEAD %v is not the release commit message of %v"
+	ErrStrFormattedReleaseWithoutBase = "the release commit of %v has no single parent to move back to"
+	ErrStrFormattedListingRemote      = "listing the references of remote %v"
+	ErrStrFormattedTagAlreadyPushed   = "tag %v is already pushed to remote %v"
+	ErrStrFormattedRestoringAliasTag  = "restoring alias tag %s"
+)
+
+// HeadRelease returns the release whose tag points at HEAD, when HEAD is the release commit of a branch.
+// The first line of its message must be the one rendered from the commit message template for the release.
+func (i *Instance) HeadRelease() (*ReleaseTag, error) {
+	head, err := i.Repository.Head()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingHead)
+	} else if !head.Name().IsBranch() {
+		return nil, errors.New(ErrStrUndoDetachedHead)
+	}
+
+	tags, err := i.ReleaseTags()
+	if err != nil {
+		return nil, err
+	}
+
+	var release *ReleaseTag
+	oldVersion := ""
+	for j := range tags {
+		if release == nil && tags[j].Commit.Hash == head.Hash() {
+			release = &tags[j]
+		} else if release != nil {
+			oldVersion = tags[j].Version
+			break
+		}
+	}
+	if release == nil {
+		return nil, fmt.Errorf(ErrStrFormattedHeadNotTagged, head.Hash().String()[:ShortHashLength])
+	}
+
+	commit := release.Commit
+	if commit.NumParents() != 1 {
+		return nil, fmt.Errorf(ErrStrFormattedReleaseWithoutBase, release.Name)
+	}
+	files, err := changedFiles(commit)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrUndoing)
+	}
+
+	message, err := i.CommitMessage(&Release{
+		Version:    release.Version,
+		OldVersion: oldVersion,
+		Files:      files,
+		Date:       commit.Author.When,
+		Tag:        release.Name,
+		Author:     fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrUndoing)
+	}
+	if firstLine(message) != firstLine(commit.Message) {
+		return nil, fmt.Errorf(ErrStrFormattedNotAReleaseCommit, head.Hash().String()[:ShortHashLength], release.Name)
+	}
+
+	return release, nil
+}
+
+// CheckUnpushed refuses to undo release when the configured remote already has its tag, one of the other release tags of its
+// commit, or one of its aliases pointing at the release. Aliases which the remote has for earlier releases do not block.
+// A missing remote has none of them.
+func (i *Instance) CheckUnpushed(cfg *PushConfig, release *ReleaseTag, tags []string, aliases []string) error {
+	remoteName := cfg.RemoteName()
+	remote, err := i.Repository.Remote(remoteName)
+	if errors.Is(err, git.ErrRemoteNotFound) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedReadingRemote, remoteName)
+	} else if len(remote.Config().URLs) == 0 {
+		return fmt.Errorf(ErrStrFormattedRemoteWithoutURL, remoteName)
+	}
+
+	auth, err := cfg.Auth(remote.Config().URLs[0])
+	if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedAuthenticating, remoteName)
+	}
+	refs, err := remote.List(&git.ListOptions{Auth: auth})
+	if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, ErrStrFormattedListingRemote, remoteName)
+	}
+
+	// the alias tags of the release are the local tags, or their commit for lightweight tags
+	released := map[plumbing.Hash]bool{release.Commit.Hash: true}
+	for _, alias := range aliases {
+		if ref, err := i.Repository.Reference(plumbing.NewTagReferenceName(alias), true); err == nil {
+			released[ref.Hash()] = true
+		}
+	}
+
+	for _, ref := range refs {
+		for _, tag := range append([]string{release.Name}, tags...) {
+			if ref.Name() == plumbing.NewTagReferenceName(tag) {
+				return fmt.Errorf(ErrStrFormattedTagAlreadyPushed, tag, remoteName)
+			}
+		}
+		for _, alias := range aliases {
+			if ref.Name() == plumbing.NewTagReferenceName(alias) && released[ref.Hash()] {
+				return fmt.Errorf(ErrStrFormattedTagAlreadyPushed, alias, remoteName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Undo deletes the tag of release, the other release tags of its commit and the alias tags pointing at it, then resets the
+// branch, the index and the working tree to the parent of the commit. Alias tags are moved back to the highest remaining
+// release carrying them, signed with gpgEntity when it is not nil.
+func (i *Instance) Undo(release *ReleaseTag, tags []string, aliases []string, gpgEntity *openpgp.Entity) error {
+	status, err := i.Worktree.Status()
+	if err != nil {
+		return errors.Wrap(err, ErrStrReadingStatus)
+	}
+	for _, s := range status {
+		if (s.Staging != git.Unmodified && s.Staging != git.Untracked) || (s.Worktree != git.Unmodified && s.Worktree != git.Untracked) {
+			return errors.New(ErrStrUndoUncommittedChanges)
+		}
+	}
+
+	for _, tag := range append(append([]string{release.Name}, tags...), aliases...) {
+		if err := i.Repository.DeleteTag(tag); err != nil {
+			return errors.Wrap(err, ErrStrUndoing)
+		}
+	}
+
+	if err := i.Worktree.Reset(&git.ResetOptions{Commit: release.Commit.ParentHashes[0], Mode: git.HardReset}); err != nil {
+		return errors.Wrap(err, ErrStrUndoing)
+	}
+
+	return i.restoreAliasTags(release, aliases, gpgEntity)
+}
+
+// ReleaseTagsAt returns the tags of tagTemplates, other than release, which point at its commit, such as the tags of the
+// dependents released along with it
+func (i *Instance) ReleaseTagsAt(release *ReleaseTag, tagTemplates []string) ([]string, error) {
+	refs, err := i.Repository.Tags()
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingTags)
+	}
+
+	at := make([]string, 0)
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		if name == release.Name || !slices.ContainsFunc(tagTemplates, func(t string) bool {
+			_, ok := VersionFromTag(t, name)
+			return ok
+		}) {
+			return nil
+		}
+		commit, err := i.tagCommit(ref)
+		if err != nil {
+			return err
+		}
+		if commit.Hash == release.Commit.Hash {
+			at = append(at, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrReadingTags)
+	}
+	sort.Strings(at)
+
+	return at, nil
+}
+
+// AliasTagsAt returns the alias tags of release which point at its commit
+func (i *Instance) AliasTagsAt(release *ReleaseTag) ([]string, error) {
+	aliases, err := i.AliasTags(&Release{Version: release.Version, Tag: release.Name})
+	if err != nil {
+		return nil, errors.Wrap(err, ErrStrUndoing)
+	}
+
+	at := make([]string, 0)
+	for _, alias := range aliases {
+		ref, err := i.Repository.Reference(plumbing.NewTagReferenceName(alias), true)
+		if err != nil {
+			continue
+		}
+		commit, err := i.tagCommit(ref)
+		if err == nil && commit.Hash == release.Commit.Hash {
+			at = append(at, alias)
+		}
+	}
+
+	return at, nil
+}
+
+// restoreAliasTags creates each alias tag on the highest release tag, other than undone, whose alias tags include it
+func (i *Instance) restoreAliasTags(undone *ReleaseTag, aliases []string, gpgEntity *openpgp.Entity) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+	tags, err := i.ReleaseTags()
+	if err != nil {
+		return err
+	}
+
+	tagger := i.committer(time.Now())
+	for _, alias := range aliases {
+		for j := range tags {
+			if tags[j].Name == undone.Name {
+				continue
+			}
+			release := &Release{Version: tags[j].Version, Tag: tags[j].Name, Date: tags[j].Commit.Author.When}
+			carried, err := i.AliasTags(release)
+			if err != nil {
+				return errors.Wrapf(err, ErrStrFormattedRestoringAliasTag, alias)
+			}
+			if !slices.Contains(carried, alias) {
+				continue
+			}
+			message, err := i.TagMessage(release)
+			if err != nil {
+				return errors.Wrapf(err, ErrStrFormattedRestoringAliasTag, alias)
+			}
+			if err := i.createTag(Tag{Name: alias, Message: message}, tags[j].Commit.Hash, tagger, gpgEntity); err != nil {
+				return errors.Wrapf(err, ErrStrFormattedRestoringAliasTag, alias)
+			}
+			break
+		}
+	}
+
+	return nil
+}
+
+// changedFiles returns the sorted files changed by commit since its first parent
+func changedFiles(commit *object.Commit) ([]string, error) {
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := parent.Patch(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	for _, p := range patch.FilePatches() {
+		from, to := p.Files()
+		if to != nil {
+			files = append(files, to.Path())
+		} else if from != nil {
+			files = append(files, from.Path())
+		}
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// firstLine returns the first line of message without surrounding spaces
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(line)
+}
@@ -0,0 +1,237 @@
+package git_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-billy/v5/osfs"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/nidhhoggr/version-bump/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGit_Undo(t *testing.T) {
+	type test struct {
+		// Setup runs once 1.0.0 and 1.1.0 are released from root, and returns the remote of the push configuration
+		Setup func(a *assert.Assertions, root string, repo *gogit.Repository) string
+		// Released runs once 1.0.0 is released, before 1.1.0, and returns the remote of the push configuration
+		Released         func(a *assert.Assertions, repo *gogit.Repository) string
+		Untracked        bool
+		Sign             bool
+		ExpectedErrorStr string
+	}
+
+	commit := func(a *assert.Assertions, root string, repo *gogit.Repository, message string) plumbing.Hash {
+		a.Nil(os.WriteFile(filepath.Join(root, "NOTES"), []byte(message), 0644))
+		wt, err := repo.Worktree()
+		a.Nil(err)
+		_, err = wt.Add("NOTES")
+		a.Nil(err)
+		hash, err := wt.Commit(message, &gogit.CommitOptions{
+			Author: &object.Signature{Name: git.Username, Email: git.Email, When: time.Now()},
+		})
+		a.Nil(err)
+		return hash
+	}
+
+	remote := func(a *assert.Assertions, repo *gogit.Repository, refSpecs ...config.RefSpec) string {
+		dir := filepath.Join(t.TempDir(), "remote.git")
+		_, err := gogit.PlainInit(dir, true)
+		a.Nil(err)
+		_, err = repo.CreateRemote(&config.RemoteConfig{Name: "upstream", URLs: []string{dir}})
+		a.Nil(err)
+		if len(refSpecs) > 0 {
+			a.Nil(repo.Push(&gogit.PushOptions{RemoteName: "upstream", RefSpecs: refSpecs}))
+		}
+		return "upstream"
+	}
+
+	suite := map[string]test{
+		"Release": {},
+		"Untracked Files Are Kept": {
+			Untracked: true,
+		},
+		"Remote Without The Tag": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				return remote(a, repo, "refs/heads/master:refs/heads/master")
+			},
+		},
+		"Empty Remote": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				return remote(a, repo)
+			},
+		},
+		"Tag Already Pushed": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				return remote(a, repo, "refs/heads/master:refs/heads/master", "refs/tags/v1.1.0:refs/tags/v1.1.0")
+			},
+			ExpectedErrorStr: fmt.Sprintf(git.ErrStrFormattedTagAlreadyPushed, "v1.1.0", "upstream"),
+		},
+		"Alias Tag Of The Previous Release Pushed": {
+			Released: func(a *assert.Assertions, repo *gogit.Repository) string {
+				return remote(a, repo, "refs/heads/master:refs/heads/master", "refs/tags/v1.0.0:refs/tags/v1.0.0", "refs/tags/v1:refs/tags/v1")
+			},
+		},
+		"Signed Alias Tags": {
+			Sign: true,
+		},
+		"Dependent Tag Already Pushed": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				return remote(a, repo, "refs/tags/web-v4.0.1:refs/tags/web-v4.0.1")
+			},
+			ExpectedErrorStr: fmt.Sprintf(git.ErrStrFormattedTagAlreadyPushed, "web-v4.0.1", "upstream"),
+		},
+		"Alias Tag Already Pushed": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				return remote(a, repo, "refs/tags/v1:refs/tags/v1")
+			},
+			ExpectedErrorStr: fmt.Sprintf(git.ErrStrFormattedTagAlreadyPushed, "v1", "upstream"),
+		},
+		"Not Tagged": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				commit(a, root, repo, "fix: after the release")
+				return ""
+			},
+			ExpectedErrorStr: "is not tagged with a release",
+		},
+		"Not A Release Commit": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				hash := commit(a, root, repo, "fix: tagged by hand")
+				_, err := repo.CreateTag("v1.1.1", hash, nil)
+				a.Nil(err)
+				return ""
+			},
+			ExpectedErrorStr: "is not the release commit message of v1.1.1",
+		},
+		"Uncommitted Changes": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				a.Nil(os.WriteFile(filepath.Join(root, "VERSION"), []byte("1.1.0-dirty\n"), 0644))
+				return ""
+			},
+			ExpectedErrorStr: git.ErrStrUndoUncommittedChanges,
+		},
+		"Detached HEAD": {
+			Setup: func(a *assert.Assertions, root string, repo *gogit.Repository) string {
+				head, err := repo.Head()
+				a.Nil(err)
+				a.Nil(repo.Storer.SetReference(plumbing.NewHashReference(plumbing.HEAD, head.Hash())))
+				return ""
+			},
+			ExpectedErrorStr: git.ErrStrUndoDetachedHead,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+		t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+
+		root := t.TempDir()
+		repo, err := gogit.PlainInit(root, false)
+		a.Nil(err)
+		initial := commit(a, root, repo, "initial")
+
+		i, err := git.New(osfs.New(filepath.Join(root, ".git")), osfs.New(root))
+		a.Nil(err)
+		i.Config.User.Name = git.Username
+		i.Config.User.Email = git.Email
+		i.CommitMessageTemplate = "release: v{{.Version}}\n\nFiles: {{range .Files}}{{.}} {{end}}"
+		i.AliasTagTemplates = []string{"v{{major}}"}
+
+		var entity *openpgp.Entity
+		if test.Sign {
+			entity, err = openpgp.NewEntity(git.Username, "", git.Email, nil)
+			a.Nil(err)
+		}
+
+		cfg := &git.PushConfig{}
+		hashes := make([]plumbing.Hash, 0)
+		// each release cascades to a dependent package tagged on the same commit
+		dependents := map[string]string{"1.0.0": "web-v4.0.0", "1.1.0": "web-v4.0.1"}
+		for _, v := range []string{"1.0.0", "1.1.0"} {
+			a.Nil(os.WriteFile(filepath.Join(root, "VERSION"), []byte(v+"\n"), 0644))
+			a.Nil(i.Save(&git.Release{Version: v, Files: []string{"VERSION"}}, entity, git.Tag{Name: dependents[v], Message: dependents[v]}))
+			head, err := repo.Head()
+			a.Nil(err)
+			hashes = append(hashes, head.Hash())
+			if v == "1.0.0" && test.Released != nil {
+				cfg.Remote = test.Released(a, repo)
+			}
+		}
+
+		if test.Untracked {
+			a.Nil(os.WriteFile(filepath.Join(root, "untracked"), []byte("kept"), 0644))
+		}
+		if test.Setup != nil {
+			cfg.Remote = test.Setup(a, root, repo)
+		}
+
+		release, err := i.HeadRelease()
+		if err == nil {
+			var tags, aliases []string
+			tags, err = i.ReleaseTagsAt(release, []string{"web-v{{version}}", "api-v{{version}}"})
+			a.Nil(err)
+			a.Equal([]string{"web-v4.0.1"}, tags)
+			aliases, err = i.AliasTagsAt(release)
+			a.Nil(err)
+			a.Equal([]string{"v1"}, aliases)
+			err = i.CheckUnpushed(cfg, release, tags, aliases)
+			if err == nil {
+				err = i.Undo(release, tags, aliases, entity)
+			}
+		}
+
+		_, tagErr := repo.Tag("web-v4.0.0")
+		a.Nil(tagErr)
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			_, tagErr = repo.Tag("v1.1.0")
+			a.Nil(tagErr)
+			_, tagErr = repo.Tag("web-v4.0.1")
+			a.Nil(tagErr)
+			continue
+		}
+		a.Nil(err)
+		_, err = repo.Tag("web-v4.0.1")
+		a.ErrorIs(err, gogit.ErrTagNotFound)
+
+		head, err := repo.Head()
+		a.Nil(err)
+		a.Equal(plumbing.NewBranchReferenceName("master"), head.Name())
+		a.Equal(hashes[0], head.Hash())
+		a.NotEqual(initial, head.Hash())
+
+		_, err = repo.Tag("v1.1.0")
+		a.ErrorIs(err, gogit.ErrTagNotFound)
+		alias, err := repo.Tag("v1")
+		a.Nil(err)
+		aliasTag, err := repo.TagObject(alias.Hash())
+		a.Nil(err)
+		a.Equal(hashes[0], aliasTag.Target)
+		a.Equal(test.Sign, aliasTag.PGPSignature != "")
+
+		content, err := os.ReadFile(filepath.Join(root, "VERSION"))
+		a.Nil(err)
+		a.Equal("1.0.0\n", string(content))
+		wt, err := repo.Worktree()
+		a.Nil(err)
+		status, err := wt.Status()
+		a.Nil(err)
+		if test.Untracked {
+			a.Equal(gogit.Untracked, status.File("untracked").Worktree)
+			delete(status, "untracked")
+		}
+		a.True(status.IsClean())
+	}
+}
@@ -2,14 +2,122 @@ package docker
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+
 	"github.com/nidhhoggr/version-bump/version"
 )
 
 const Name = "Docker"
 
-var Files = []string{"Dockerfile"}
+var Files = []string{
+	"Dockerfile",
+	"docker-compose*.yml",
+	"docker-compose*.yaml",
+	"compose*.yml",
+	"compose*.yaml",
+	"kustomization.yml",
+	"kustomization.yaml",
+}
 
 var Regex = []string{
 	fmt.Sprintf("^LABEL .*org.opencontainers.image.version['\"= ]*(?P<version>%v)['\"]?.*", version.Regex),
 	fmt.Sprintf("^\\s*['\"]?org.opencontainers.image.version['\"= ]*(?P<version>%v)['\"]?.*", version.Regex),
+	fmt.Sprintf("^\\s*(?:ARG|ENV)\\s+(?:APP_)?VERSION\\s*[= ]\\s*['\"]?(?P<version>%v)['\"]?\\s*$", version.Regex),
+}
+
+var (
+	imageLineRegex    = regexp.MustCompile(`^\s*(?:-\s+)?image:\s*['"]?([^'"\s]+)['"]?\s*(?:#.*)?$`)
+	listItemRegex     = regexp.MustCompile(`^(\s*)-\s`)
+	kustomizeKeyRegex = regexp.MustCompile(`^\s*(?:-\s+)?(name|newName|newTag):\s*['"]?([^'"\s]+)['"]?\s*(?:#.*)?$`)
+	tagRegex          = regexp.MustCompile(fmt.Sprintf("^%v$", version.Regex))
+)
+
+// kustomizeImage accumulates the fields of a single kustomization.yaml images[] entry
+type kustomizeImage struct {
+	names  []string
+	newTag []int
+}
+
+// LocateImageTags returns the byte offsets of the version tags of the given images.
+// Both `image: name:tag` references (compose files, Kubernetes manifests) and
+// kustomization.yaml `images[].newTag` entries are recognised.
+// Images which are not listed are never matched.
+func LocateImageTags(content []byte, images []string) [][]int {
+	locations := make([][]int, 0)
+	if len(images) == 0 {
+		return locations
+	}
+
+	var item *kustomizeImage
+	itemIndent := -1
+
+	flush := func() {
+		if item != nil && item.newTag != nil && containsAny(images, item.names) {
+			locations = append(locations, item.newTag)
+		}
+		item = nil
+		itemIndent = -1
+	}
+
+	offset := 0
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		trimmed := strings.TrimRight(line, "\r\n")
+
+		if m := listItemRegex.FindStringSubmatch(trimmed); m != nil {
+			flush()
+			item = new(kustomizeImage)
+			itemIndent = len(m[1])
+		} else if item != nil && strings.TrimSpace(trimmed) != "" && indentOf(trimmed) <= itemIndent {
+			flush()
+		}
+
+		if m := imageLineRegex.FindStringSubmatchIndex(trimmed); m != nil {
+			ref := trimmed[m[2]:m[3]]
+			if i := strings.LastIndex(ref, ":"); i > 0 && !strings.Contains(ref[i:], "/") {
+				if contains(images, ref[:i]) && tagRegex.MatchString(ref[i+1:]) {
+					locations = append(locations, []int{offset + m[2] + i + 1, offset + m[3]})
+				}
+			}
+		} else if item != nil {
+			if m := kustomizeKeyRegex.FindStringSubmatchIndex(trimmed); m != nil {
+				value := trimmed[m[4]:m[5]]
+				switch trimmed[m[2]:m[3]] {
+				case "newTag":
+					if tagRegex.MatchString(value) {
+						item.newTag = []int{offset + m[4], offset + m[5]}
+					}
+				default:
+					item.names = append(item.names, value)
+				}
+			}
+		}
+
+		offset += len(line)
+	}
+	flush()
+
+	return locations
+}
+
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " \t"))
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, h := range haystack {
+		if h == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(haystack []string, needles []string) bool {
+	for _, n := range needles {
+		if contains(haystack, n) {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,84 @@
+package docker_test
+
+import (
+	"testing"
+
+	"github.com/nidhhoggr/version-bump/langs/docker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocker_LocateImageTags(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Content          string
+		Images           []string
+		ExpectedVersions []string
+	}
+
+	suite := map[string]test{
+		"Compose": {
+			Content: `services:
+  api:
+    image: ghcr.io/acme/api:1.2.3
+  db:
+    image: "postgres:16.1.0"
+  web:
+    image: 'ghcr.io/acme/web:v1.2.3' # pinned
+`,
+			Images:           []string{"ghcr.io/acme/api", "ghcr.io/acme/web"},
+			ExpectedVersions: []string{"1.2.3", "v1.2.3"},
+		},
+		"Kubernetes Manifest": {
+			Content: `apiVersion: apps/v1
+kind: Deployment
+spec:
+  template:
+    spec:
+      containers:
+        - name: api
+          image: localhost:5000/acme/api:2.0.0-rc.1
+        - name: sidecar
+          image: envoyproxy/envoy:1.28.0
+`,
+			Images:           []string{"localhost:5000/acme/api"},
+			ExpectedVersions: []string{"2.0.0-rc.1"},
+		},
+		"Kustomization": {
+			Content: `resources:
+  - deployment.yaml
+images:
+  - name: ghcr.io/acme/api
+    newTag: 1.2.3
+  - newTag: 9.9.9
+    name: redis
+  - newTag: "1.2.3"
+    name: ghcr.io/acme/placeholder
+    newName: ghcr.io/acme/web
+`,
+			Images:           []string{"ghcr.io/acme/api", "ghcr.io/acme/web"},
+			ExpectedVersions: []string{"1.2.3", "1.2.3"},
+		},
+		"No Images Configured": {
+			Content:          "image: ghcr.io/acme/api:1.2.3\n",
+			ExpectedVersions: []string{},
+		},
+		"Non Semver Tag": {
+			Content:          "image: ghcr.io/acme/api:latest\n",
+			Images:           []string{"ghcr.io/acme/api"},
+			ExpectedVersions: []string{},
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		versions := make([]string, 0)
+		for _, location := range docker.LocateImageTags([]byte(test.Content), test.Images) {
+			versions = append(versions, test.Content[location[0]:location[1]])
+		}
+		a.Equal(test.ExpectedVersions, versions)
+	}
+}
@@ -2,6 +2,11 @@ package golang
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"regexp"
+
 	"github.com/nidhhoggr/version-bump/version"
 )
 
@@ -9,8 +14,63 @@ const Name = "Go"
 
 var Files = []string{"*.go"}
 
+// Identifiers are the names of package level constants and variables holding the version
+var Identifiers = []string{"Version", "AppVersion", "version"}
+
+// Regex is used for files which cannot be parsed as Go source
 var Regex = []string{
 	fmt.Sprintf("^const [vV]ersion\\s*string = \"(?P<version>%v)\"", version.Regex),
 	fmt.Sprintf("^const [vV]ersion := \"(?P<version>%v)\"", version.Regex),
 	fmt.Sprintf("^\\s*[vV]ersion\\s*string = \"(?P<version>%v)\"", version.Regex),
 }
+
+var versionRegex = regexp.MustCompile(fmt.Sprintf("^%v$", version.Regex))
+
+// LocateVersion parses Go source and returns the byte offsets of the contents of every
+// string literal assigned to one of the identifiers by a package level const or var declaration.
+// Grouped declarations, typed constants and raw string literals are all supported.
+func LocateVersion(content []byte, identifiers []string) ([][]int, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", content, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make([][]int, 0)
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || (genDecl.Tok != token.CONST && genDecl.Tok != token.VAR) {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if i >= len(valueSpec.Values) || !isIdentifier(name.Name, identifiers) {
+					continue
+				}
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING || len(lit.Value) < 2 {
+					continue
+				}
+				// strip the surrounding quotes or backticks
+				value := lit.Value[1 : len(lit.Value)-1]
+				if !versionRegex.MatchString(value) {
+					continue
+				}
+				start := fset.Position(lit.Pos()).Offset + 1
+				locations = append(locations, []int{start, start + len(value)})
+			}
+		}
+	}
+
+	return locations, nil
+}
+
+func isIdentifier(name string, identifiers []string) bool {
+	for _, identifier := range identifiers {
+		if name == identifier {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/nidhhoggr/version-bump/langs/golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGolang_LocateVersion(t *testing.T) {
+	a := assert.New(t)
+
+	type test struct {
+		Content          string
+		Identifiers      []string
+		ExpectedVersions []string
+		ExpectedError    bool
+	}
+
+	suite := map[string]test{
+		"Typed Constant": {
+			Content: `package main
+
+const Version string = "1.2.3"`,
+			ExpectedVersions: []string{"1.2.3"},
+		},
+		"Untyped Variable": {
+			Content: `package main
+
+var Version = "v1.2.3"`,
+			ExpectedVersions: []string{"v1.2.3"},
+		},
+		"Grouped And Aligned": {
+			Content: `package main
+
+const (
+	Name                  = "app"
+	AppVersion     string = "2.0.0-rc.1"
+	SomeLongerName string = "3.0.0"
+)`,
+			ExpectedVersions: []string{"2.0.0-rc.1"},
+		},
+		"Raw String Literal": {
+			Content:          "package main\n\nconst version = `0.1.0`\n",
+			ExpectedVersions: []string{"0.1.0"},
+		},
+		"Multiple Names In One Spec": {
+			Content: `package main
+
+var Name, Version = "app", "1.0.0"`,
+			ExpectedVersions: []string{"1.0.0"},
+		},
+		"Custom Identifiers": {
+			Content: `package main
+
+const Version = "1.0.0"
+const BuildVersion = "4.5.6"`,
+			Identifiers:      []string{"BuildVersion"},
+			ExpectedVersions: []string{"4.5.6"},
+		},
+		"Not A Version": {
+			Content: `package main
+
+var Version = "dev"`,
+			ExpectedVersions: []string{},
+		},
+		"Function Scope Ignored": {
+			Content: `package main
+
+func main() {
+	const Version = "1.0.0"
+}`,
+			ExpectedVersions: []string{},
+		},
+		"Invalid Source": {
+			Content:       `const Version := "1.2.3"`,
+			ExpectedError: true,
+		},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+
+		identifiers := golang.Identifiers
+		if len(test.Identifiers) > 0 {
+			identifiers = test.Identifiers
+		}
+
+		locations, err := golang.LocateVersion([]byte(test.Content), identifiers)
+		if test.ExpectedError {
+			a.Error(err)
+			continue
+		}
+		a.Nil(err)
+
+		versions := make([]string, 0)
+		for _, location := range locations {
+			versions = append(versions, test.Content[location[0]:location[1]])
+		}
+		a.Equal(test.ExpectedVersions, versions)
+	}
+}
@@ -0,0 +1,189 @@
+package golang
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+const (
+	// ModFile is the name of the file declaring a Go module
+	ModFile = "go.mod"
+	// WorkFile is the name of the file declaring a Go workspace
+	WorkFile = "go.work"
+)
+
+// Module is a Go module which is released with its own version and tags
+type Module struct {
+	Dir  string
+	Path string
+}
+
+// TagPrefix returns the prefix Go expects on the version tags of the module,
+// e.g. tools/cli/ for a module in the tools/cli directory
+func (m *Module) TagPrefix() string {
+	if m.Dir == "" || m.Dir == "." {
+		return ""
+	}
+	return m.Dir + "/"
+}
+
+// WorkspaceDirs returns the module directories listed by the use directives of a go.work file
+func WorkspaceDirs(gowork []byte) ([]string, error) {
+	f, err := modfile.ParseWork(WorkFile, gowork, nil)
+	if err != nil {
+		return nil, err
+	}
+	dirs := make([]string, 0, len(f.Use))
+	for _, use := range f.Use {
+		dirs = append(dirs, path.Clean(use.Path))
+	}
+	return dirs, nil
+}
+
+// SetRequire updates a requirement on the module oldPath to newPath at the given version.
+// Replace directives of oldPath follow a change of the module path.
+// It reports whether the go.mod file required oldPath at all.
+func SetRequire(gomod []byte, oldPath string, newPath string, version string) ([]byte, bool, error) {
+	f, err := modfile.Parse(ModFile, gomod, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	required := false
+	for _, r := range f.Require {
+		if r.Mod.Path == oldPath {
+			required = true
+		}
+	}
+	if !required {
+		return gomod, false, nil
+	}
+
+	if oldPath != newPath {
+		if err = f.DropRequire(oldPath); err != nil {
+			return nil, false, err
+		}
+		replaces := make([]modfile.Replace, 0)
+		for _, r := range f.Replace {
+			if r.Old.Path == oldPath {
+				replaces = append(replaces, *r)
+			}
+		}
+		for _, r := range replaces {
+			if err = f.DropReplace(oldPath, r.Old.Version); err != nil {
+				return nil, false, err
+			}
+			if err = f.AddReplace(newPath, r.Old.Version, r.New.Path, r.New.Version); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	if err = f.AddRequire(newPath, version); err != nil {
+		return nil, false, err
+	}
+	f.Cleanup()
+
+	return modfile.Format(f.Syntax), true, nil
+}
+
+// ReplacesLocally reports whether a go.mod file replaces every version of the module modPath with a local directory,
+// in which case the required version is not resolved from the tags of the module
+func ReplacesLocally(gomod []byte, modPath string) bool {
+	f, err := modfile.Parse(ModFile, gomod, nil)
+	if err != nil {
+		return false
+	}
+	for _, r := range f.Replace {
+		if r.Old.Path == modPath && r.Old.Version == "" && r.New.Version == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// ModulePathForMajor returns the module path required by Go for the given major version.
+// Major versions 0 and 1 have no suffix, later versions end with /vN (.vN for gopkg.in).
+func ModulePathForMajor(modPath string, major uint64) string {
+	prefix, _, ok := module.SplitPathVersion(modPath)
+	if !ok {
+		prefix = modPath
+	}
+	if strings.HasPrefix(prefix, "gopkg.in/") {
+		return fmt.Sprintf("%s.v%d", prefix, major)
+	}
+	if major < 2 {
+		return prefix
+	}
+	return fmt.Sprintf("%s/v%d", prefix, major)
+}
+
+// SetModulePath rewrites the module directive of a go.mod file
+func SetModulePath(gomod []byte, modPath string) ([]byte, error) {
+	f, err := modfile.Parse(ModFile, gomod, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = f.AddModuleStmt(modPath); err != nil {
+		return nil, err
+	}
+	return modfile.Format(f.Syntax), nil
+}
+
+// RewriteImports replaces imports of oldPath, and of the packages beneath it, with newPath.
+// Only the bytes of the affected import paths are rewritten. It reports whether anything changed.
+func RewriteImports(src []byte, oldPath string, newPath string) ([]byte, bool, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ImportsOnly)
+	if err != nil {
+		return nil, false, err
+	}
+
+	type replacement struct {
+		start, end int
+		value      string
+	}
+
+	replacements := make([]replacement, 0)
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, false, err
+		}
+		if importPath != oldPath && !strings.HasPrefix(importPath, oldPath+"/") {
+			continue
+		}
+		start := fset.Position(spec.Path.Pos()).Offset
+		replacements = append(replacements, replacement{
+			start: start,
+			end:   start + len(spec.Path.Value),
+			value: strconv.Quote(newPath + strings.TrimPrefix(importPath, oldPath)),
+		})
+	}
+
+	if len(replacements) == 0 {
+		return src, false, nil
+	}
+
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].start < replacements[j].start
+	})
+
+	res := make([]byte, 0, len(src))
+	last := 0
+	for _, r := range replacements {
+		res = append(res, src[last:r.start]...)
+		res = append(res, r.value...)
+		last = r.end
+	}
+	res = append(res, src[last:]...)
+
+	return res, true, nil
+}
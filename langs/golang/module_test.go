@@ -0,0 +1,169 @@
+package golang_test
+
+import (
+	"testing"
+
+	"github.com/nidhhoggr/version-bump/langs/golang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGolang_ModulePathForMajor(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("example.com/acme", golang.ModulePathForMajor("example.com/acme", 1))
+	a.Equal("example.com/acme/v2", golang.ModulePathForMajor("example.com/acme", 2))
+	a.Equal("example.com/acme/v3", golang.ModulePathForMajor("example.com/acme/v2", 3))
+	a.Equal("example.com/acme", golang.ModulePathForMajor("example.com/acme/v2", 0))
+	a.Equal("gopkg.in/yaml.v4", golang.ModulePathForMajor("gopkg.in/yaml.v3", 4))
+}
+
+func TestGolang_SetModulePath(t *testing.T) {
+	a := assert.New(t)
+
+	res, err := golang.SetModulePath([]byte(`module example.com/acme
+
+go 1.23
+
+require golang.org/x/mod v0.21.0 // indirect
+`), "example.com/acme/v2")
+	a.Nil(err)
+	a.Equal(`module example.com/acme/v2
+
+go 1.23
+
+require golang.org/x/mod v0.21.0 // indirect
+`, string(res))
+
+	_, err = golang.SetModulePath([]byte(`module`), "example.com/acme/v2")
+	a.Error(err)
+}
+
+func TestGolang_RewriteImports(t *testing.T) {
+	a := assert.New(t)
+
+	src := `package main
+
+import (
+	"fmt"
+
+	acme "example.com/acme"
+	"example.com/acme/internal/lib"
+	"example.com/acmetools/x"
+)
+`
+	res, changed, err := golang.RewriteImports([]byte(src), "example.com/acme", "example.com/acme/v2")
+	a.Nil(err)
+	a.True(changed)
+	a.Equal(`package main
+
+import (
+	"fmt"
+
+	acme "example.com/acme/v2"
+	"example.com/acme/v2/internal/lib"
+	"example.com/acmetools/x"
+)
+`, string(res))
+
+	res, changed, err = golang.RewriteImports([]byte("package main\n\nimport \"fmt\"\n"), "example.com/acme", "example.com/acme/v2")
+	a.Nil(err)
+	a.False(changed)
+	a.Equal("package main\n\nimport \"fmt\"\n", string(res))
+
+	_, _, err = golang.RewriteImports([]byte("not go"), "example.com/acme", "example.com/acme/v2")
+	a.Error(err)
+}
+
+func TestGolang_ModuleTagPrefix(t *testing.T) {
+	a := assert.New(t)
+
+	a.Equal("", (&golang.Module{Dir: ".", Path: "example.com/acme"}).TagPrefix())
+	a.Equal("tools/cli/", (&golang.Module{Dir: "tools/cli", Path: "example.com/acme/tools/cli"}).TagPrefix())
+}
+
+func TestGolang_WorkspaceDirs(t *testing.T) {
+	a := assert.New(t)
+
+	dirs, err := golang.WorkspaceDirs([]byte(`go 1.23
+
+use (
+	.
+	./tools/cli
+)
+`))
+	a.Nil(err)
+	a.Equal([]string{".", "tools/cli"}, dirs)
+
+	_, err = golang.WorkspaceDirs([]byte(`use (`))
+	a.Error(err)
+}
+
+func TestGolang_SetRequire(t *testing.T) {
+	a := assert.New(t)
+
+	gomod := `module example.com/acme
+
+go 1.23
+
+require (
+	example.com/acme/tools/cli v1.3.0
+	golang.org/x/mod v0.21.0
+)
+
+replace example.com/acme/tools/cli => ./tools/cli
+`
+
+	res, required, err := golang.SetRequire([]byte(gomod), "example.com/acme/tools/cli", "example.com/acme/tools/cli", "v1.4.0")
+	a.Nil(err)
+	a.True(required)
+	a.Equal(`module example.com/acme
+
+go 1.23
+
+require (
+	example.com/acme/tools/cli v1.4.0
+	golang.org/x/mod v0.21.0
+)
+
+replace example.com/acme/tools/cli => ./tools/cli
+`, string(res))
+
+	res, required, err = golang.SetRequire([]byte(gomod), "example.com/acme/tools/cli", "example.com/acme/tools/cli/v2", "v2.0.0")
+	a.Nil(err)
+	a.True(required)
+	a.Contains(string(res), "example.com/acme/tools/cli/v2 v2.0.0")
+	a.Contains(string(res), "replace example.com/acme/tools/cli/v2 => ./tools/cli")
+	a.NotContains(string(res), "example.com/acme/tools/cli v1.3.0")
+
+	res, required, err = golang.SetRequire([]byte(gomod), "example.com/other", "example.com/other", "v1.0.0")
+	a.Nil(err)
+	a.False(required)
+	a.Equal(gomod, string(res))
+}
+
+func TestGolang_ReplacesLocally(t *testing.T) {
+	a := assert.New(t)
+
+	gomod := `module example.com/acme
+
+go 1.23
+
+require (
+	example.com/acme/tools/cli v1.3.0
+	example.com/acme/api v1.0.0
+	golang.org/x/mod v0.21.0
+)
+
+replace example.com/acme/tools/cli => ./tools/cli
+
+replace example.com/acme/api v1.0.0 => ./api
+
+replace golang.org/x/mod => golang.org/x/mod v0.20.0
+`
+
+	a.True(golang.ReplacesLocally([]byte(gomod), "example.com/acme/tools/cli"))
+	a.False(golang.ReplacesLocally([]byte(gomod), "example.com/acme/api"))
+	a.False(golang.ReplacesLocally([]byte(gomod), "golang.org/x/mod"))
+	a.False(golang.ReplacesLocally([]byte(gomod), "example.com/other"))
+	a.False(golang.ReplacesLocally([]byte("module"), "example.com/acme/tools/cli"))
+}
@@ -2,18 +2,33 @@ package langs
 
 import (
 	"fmt"
+	"github.com/nidhhoggr/version-bump/changelog"
+	"github.com/nidhhoggr/version-bump/git"
 	"github.com/nidhhoggr/version-bump/langs/docker"
 	"github.com/nidhhoggr/version-bump/langs/golang"
 	"github.com/nidhhoggr/version-bump/langs/js"
+	"github.com/nidhhoggr/version-bump/langs/plaintext"
 	"github.com/nidhhoggr/version-bump/version"
+	"github.com/pkg/errors"
 )
 
+// ErrNoVersionLocated is returned by a Locator which read a file without finding any version, so that the default Regex and
+// JSONFields are not matched against it either
+var ErrNoVersionLocated = errors.New("no version found")
+
+// Locator returns the byte offsets [start, end] of every version string found in the content of a file.
+// Versions located in a file take precedence over Regex and JSONFields matches, which are used when nothing is located.
+type Locator func(content []byte, config *Config) ([][]int, error)
+
 // DefaultSettings these settings can be overridden by Config
 type DefaultSettings struct {
 	Regex      *[]string
 	JSONFields *[]string
+	Locate     Locator
 	Name       string
 	Files      []string
+	// SingleVersion requires matched files to contain nothing but one version
+	SingleVersion bool
 }
 
 // Config value populated from the .bump file which override DefaultSettings
@@ -24,33 +39,77 @@ type Config struct {
 	Files        []string
 	Directories  []string
 	ExcludeFiles []string `toml:"exclude_files"`
-	Enabled      bool
+	// Images restricts Docker image tag updates to these image names
+	Images []string
+	// Identifiers overrides the names of the Go constants and variables holding the version
+	Identifiers []string
+	Enabled     bool
+	// MigrateModule adds the /vN suffix to the Go module path and its imports on major version changes
+	MigrateModule bool `toml:"migrate_module"`
 }
 
-// ConfigDecoder used to parse the .bump toml file
-type ConfigDecoder struct {
+// LanguagesDecoder used to parse the language sections of the .bump toml file
+type LanguagesDecoder struct {
 	Generic    []Config
 	Docker     Config
 	Go         Config
 	JavaScript Config
+	PlainText  Config
+}
+
+// PackageDecoder used to parse a [[package]] section of the .bump toml file
+type PackageDecoder struct {
+	LanguagesDecoder
+	Name        string
+	Tag         string
+	Directories []string
+}
+
+// DependentsConfig used to parse the [dependents] section of the .bump toml file
+type DependentsConfig struct {
+	// Update rewrites the version constraints of packages depending on a released package
+	Update bool
+	// Cascade releases a patch version of every package whose constraints were updated
+	Cascade bool
+}
+
+// ConfigDecoder used to parse the .bump toml file
+type ConfigDecoder struct {
+	LanguagesDecoder
+	Package    []PackageDecoder
+	Dependents DependentsConfig
+	Changelog  changelog.Config
+	Git        git.Config
+	Push       git.PushConfig
+	Checks     git.ChecksConfig
+	Describe   git.DescribeConfig
+	Branches   git.BranchesConfig
 }
 
 var Languages = []DefaultSettings{
 	{
-		Name:  docker.Name,
-		Files: docker.Files,
-		Regex: &docker.Regex,
+		Name:   docker.Name,
+		Files:  docker.Files,
+		Regex:  &docker.Regex,
+		Locate: locateDockerImageTags,
 	},
 	{
-		Name:  golang.Name,
-		Files: golang.Files,
-		Regex: &golang.Regex,
+		Name:   golang.Name,
+		Files:  golang.Files,
+		Regex:  &golang.Regex,
+		Locate: locateGoVersion,
 	},
 	{
 		Name:       js.Name,
 		Files:      js.Files,
 		JSONFields: &js.JSONFields,
 	},
+	{
+		Name:          plaintext.Name,
+		Files:         plaintext.Files,
+		Regex:         &plaintext.Regex,
+		SingleVersion: true,
+	},
 }
 
 var Supported map[string]*DefaultSettings
@@ -75,6 +134,25 @@ func GetLanguageByName(langName string) *DefaultSettings {
 	return langSettings
 }
 
+func locateDockerImageTags(content []byte, config *Config) ([][]int, error) {
+	return docker.LocateImageTags(content, config.Images), nil
+}
+
+func locateGoVersion(content []byte, config *Config) ([][]int, error) {
+	identifiers := golang.Identifiers
+	if len(config.Identifiers) > 0 {
+		identifiers = config.Identifiers
+	}
+	locations, err := golang.LocateVersion(content, identifiers)
+	if err != nil || (len(locations) == 0 && len(config.Regex) > 0) {
+		// files which are not valid Go source, or without a located version when Regex is configured, fall back to Regex
+		return [][]int{}, nil
+	} else if len(locations) == 0 {
+		return nil, ErrNoVersionLocated
+	}
+	return locations, nil
+}
+
 func (c *Config) GetDirectories() []string {
 	if len(c.Directories) == 0 {
 		c.Directories = []string{"."}
@@ -4,6 +4,7 @@ import (
 	"github.com/nidhhoggr/version-bump/langs/docker"
 	"github.com/nidhhoggr/version-bump/langs/golang"
 	"github.com/nidhhoggr/version-bump/langs/js"
+	"github.com/nidhhoggr/version-bump/langs/plaintext"
 	"testing"
 
 	"github.com/nidhhoggr/version-bump/langs"
@@ -14,7 +15,8 @@ func TestLangs_New(t *testing.T) {
 	a := assert.New(t)
 
 	type test struct {
-		ExpectedResult *langs.DefaultSettings
+		ExpectedResult  *langs.DefaultSettings
+		ExpectedLocator bool
 	}
 
 	suite := map[string]test{
@@ -24,6 +26,7 @@ func TestLangs_New(t *testing.T) {
 				Files: docker.Files,
 				Regex: &docker.Regex,
 			},
+			ExpectedLocator: true,
 		},
 		"Go": {
 			ExpectedResult: &langs.DefaultSettings{
@@ -31,6 +34,7 @@ func TestLangs_New(t *testing.T) {
 				Files: golang.Files,
 				Regex: &golang.Regex,
 			},
+			ExpectedLocator: true,
 		},
 		"JavaScript": {
 			ExpectedResult: &langs.DefaultSettings{
@@ -39,6 +43,14 @@ func TestLangs_New(t *testing.T) {
 				JSONFields: &js.JSONFields,
 			},
 		},
+		"PlainText": {
+			ExpectedResult: &langs.DefaultSettings{
+				Name:          plaintext.Name,
+				Files:         plaintext.Files,
+				Regex:         &plaintext.Regex,
+				SingleVersion: true,
+			},
+		},
 		"Not Supported DefaultSettings": {
 			ExpectedResult: nil,
 		},
@@ -54,7 +66,10 @@ func TestLangs_New(t *testing.T) {
 		if name == "Not Supported DefaultSettings" {
 			a.Equal(test.ExpectedResult, r)
 		} else {
-			a.EqualValues(test.ExpectedResult, r)
+			a.Equal(test.ExpectedLocator, r.Locate != nil)
+			settings := *r
+			settings.Locate = nil
+			a.EqualValues(test.ExpectedResult, &settings)
 		}
 	}
 }
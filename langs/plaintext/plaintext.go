@@ -0,0 +1,18 @@
+package plaintext
+
+import (
+	"fmt"
+	"github.com/nidhhoggr/version-bump/version"
+)
+
+// Name of files which contain nothing but a version string
+const Name = "PlainText"
+
+var Files = []string{
+	"VERSION",
+	"version.txt",
+}
+
+var Regex = []string{
+	fmt.Sprintf("^\\s*(?P<version>%v)\\s*$", version.Regex),
+}
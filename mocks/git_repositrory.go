@@ -7,8 +7,12 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	object "github.com/go-git/go-git/v5/plumbing/object"
+
 	plumbing "github.com/go-git/go-git/v5/plumbing"
 
+	storer "github.com/go-git/go-git/v5/plumbing/storer"
+
 	v5 "github.com/go-git/go-git/v5"
 )
 
@@ -17,6 +21,36 @@ type Repository struct {
 	mock.Mock
 }
 
+// CommitObject provides a mock function with given fields: _a0
+func (_m *Repository) CommitObject(_a0 plumbing.Hash) (*object.Commit, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitObject")
+	}
+
+	var r0 *object.Commit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(plumbing.Hash) (*object.Commit, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(plumbing.Hash) *object.Commit); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*object.Commit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(plumbing.Hash) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConfigScoped provides a mock function with given fields: _a0
 func (_m *Repository) ConfigScoped(_a0 config.Scope) (*config.Config, error) {
 	ret := _m.Called(_a0)
@@ -77,6 +111,222 @@ func (_m *Repository) CreateTag(_a0 string, _a1 plumbing.Hash, _a2 *v5.CreateTag
 	return r0, r1
 }
 
+// DeleteTag provides a mock function with given fields: _a0
+func (_m *Repository) DeleteTag(_a0 string) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Head provides a mock function with given fields:
+func (_m *Repository) Head() (*plumbing.Reference, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Head")
+	}
+
+	var r0 *plumbing.Reference
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*plumbing.Reference, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *plumbing.Reference); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*plumbing.Reference)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Log provides a mock function with given fields: _a0
+func (_m *Repository) Log(_a0 *v5.LogOptions) (object.CommitIter, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Log")
+	}
+
+	var r0 object.CommitIter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*v5.LogOptions) (object.CommitIter, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(*v5.LogOptions) object.CommitIter); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(object.CommitIter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*v5.LogOptions) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Push provides a mock function with given fields: _a0
+func (_m *Repository) Push(_a0 *v5.PushOptions) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Push")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*v5.PushOptions) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reference provides a mock function with given fields: _a0, _a1
+func (_m *Repository) Reference(_a0 plumbing.ReferenceName, _a1 bool) (*plumbing.Reference, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reference")
+	}
+
+	var r0 *plumbing.Reference
+	var r1 error
+	if rf, ok := ret.Get(0).(func(plumbing.ReferenceName, bool) (*plumbing.Reference, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(plumbing.ReferenceName, bool) *plumbing.Reference); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*plumbing.Reference)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(plumbing.ReferenceName, bool) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remote provides a mock function with given fields: _a0
+func (_m *Repository) Remote(_a0 string) (*v5.Remote, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remote")
+	}
+
+	var r0 *v5.Remote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*v5.Remote, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) *v5.Remote); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v5.Remote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TagObject provides a mock function with given fields: _a0
+func (_m *Repository) TagObject(_a0 plumbing.Hash) (*object.Tag, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagObject")
+	}
+
+	var r0 *object.Tag
+	var r1 error
+	if rf, ok := ret.Get(0).(func(plumbing.Hash) (*object.Tag, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(plumbing.Hash) *object.Tag); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*object.Tag)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(plumbing.Hash) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Tags provides a mock function with given fields:
+func (_m *Repository) Tags() (storer.ReferenceIter, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tags")
+	}
+
+	var r0 storer.ReferenceIter
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (storer.ReferenceIter, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() storer.ReferenceIter); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(storer.ReferenceIter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Worktree provides a mock function with given fields:
 func (_m *Repository) Worktree() (*v5.Worktree, error) {
 	ret := _m.Called()
@@ -74,6 +74,54 @@ func (_m *Worktree) Commit(_a0 string, _a1 *v5.CommitOptions) (plumbing.Hash, er
 	return r0, r1
 }
 
+// Reset provides a mock function with given fields: _a0
+func (_m *Worktree) Reset(_a0 *v5.ResetOptions) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*v5.ResetOptions) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Status provides a mock function with given fields:
+func (_m *Worktree) Status() (v5.Status, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 v5.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (v5.Status, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() v5.Status); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v5.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewWorktree creates a new instance of Worktree. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewWorktree(t interface {
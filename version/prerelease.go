@@ -11,6 +11,7 @@ import (
 var (
 	ErrStrFormattedPrereleaseContainsInvalidValue = "Prerelease contains invalid value: %s"
 	ErrStrFormattedParsingPrereleasePart          = "Could not parse part '%-v' as int64"
+	ErrStrFormattedInvalidPrereleaseIdentifier    = "invalid prerelease identifier %q, expected dot separated alphanumerics and hyphens not ending with a number"
 )
 
 type Prerelease struct {
@@ -25,9 +26,11 @@ const (
 	AlphaPrerelease
 	BetaPrerelease
 	ReleaseCandidate
+	// BranchPrerelease is named after an identifier set with SetPrereleaseIdentifier, e.g. feat-login for the branch feat/login
+	BranchPrerelease
 )
 
-var PrereleaseTypeStrings = []string{"alpha", "beta", "rc"}
+var PrereleaseTypeStrings = []string{"alpha", "beta", "rc", "branch"}
 
 func PrereleaseString(ptr PrereleaseType) string {
 	if ptr == NotAPrerelease {
@@ -44,10 +47,44 @@ func FromPrereleaseTypeString(s string) PrereleaseType {
 		return BetaPrerelease
 	case PrereleaseTypeStrings[2]:
 		return ReleaseCandidate
+	case PrereleaseTypeStrings[3]:
+		return BranchPrerelease
 	}
 	return NotAPrerelease
 }
 
+// SanitizePrereleaseIdentifier turns name, e.g. a branch such as feat/Login_Form, into a prerelease identifier such as feat-login-form.
+// It is lowercased, and each run of characters other than alphanumerics and hyphens becomes a single hyphen.
+func SanitizePrereleaseIdentifier(name string) string {
+	var sb strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if strings.ContainsRune(allowed, r) && r != '-' {
+			sb.WriteRune(r)
+			hyphen = false
+		} else if !hyphen {
+			sb.WriteRune('-')
+			hyphen = true
+		}
+	}
+	return strings.Trim(sb.String(), "-")
+}
+
+// ValidatePrereleaseIdentifier follows the semver rules of prerelease identifiers, and refuses a trailing number which would be
+// mistaken for the counter of the prerelease
+func ValidatePrereleaseIdentifier(identifier string) error {
+	parts := strings.Split(identifier, ".")
+	for _, part := range parts {
+		if part == "" || !containsOnly(part, allowed) || (containsOnly(part, num) && len(part) > 1 && part[0] == '0') {
+			return fmt.Errorf(ErrStrFormattedInvalidPrereleaseIdentifier, identifier)
+		}
+	}
+	if containsOnly(parts[len(parts)-1], num) {
+		return fmt.Errorf(ErrStrFormattedInvalidPrereleaseIdentifier, identifier)
+	}
+	return nil
+}
+
 func (p *Prerelease) Length() int {
 	return p.segmentLen
 }
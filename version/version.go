@@ -12,6 +12,7 @@ import (
 var (
 	ErrStrPreReleasingNonPrerelease    = "cannot Prerelease a non-Prerelease without incrementing a version type"
 	ErrStrPrereleaseEmptyType          = "cannot Prerelease an empty type"
+	ErrStrPrereleaseEmptyIdentifier    = "cannot Prerelease a branch prerelease without an identifier"
 	ErrStrPrereleaseAlphaFromBeta      = "cannot Prerelease an alpha from an existing beta prerelease"
 	ErrStrPrereleaseNonRcFromRc        = "cannot Prerelease a non-rc from a release candidate"
 	ErrStrParsePrereleaseTag           = "could not parse prerelease tag"
@@ -27,7 +28,7 @@ const Regex = `[vV]?([0-9]*)\.([0-9]*)\.([0-9]*)(-([0-9]+[0-9A-Za-z\-~]*(\.[0-9A
 
 type Type int
 
-var TypeStrings = []string{"major", "minor", "patch"}
+var TypeStrings = []string{"major", "minor", "patch", "auto"}
 
 type SemverInterface interface {
 	IncMajor() semver.Version
@@ -42,6 +43,8 @@ type SemverInterface interface {
 
 type Version struct {
 	semverPtr SemverInterface
+	// identifier names BranchPrerelease prereleases
+	identifier string
 }
 
 const (
@@ -49,6 +52,8 @@ const (
 	Major
 	Minor
 	Patch
+	// Auto is resolved to one of the other types from the commits since the latest release
+	Auto
 )
 
 func FromString(s string) Type {
@@ -59,14 +64,39 @@ func FromString(s string) Type {
 		return Minor
 	case TypeStrings[2]:
 		return Patch
+	case TypeStrings[3]:
+		return Auto
 	}
 	return NotAVersion
 }
 
+func TypeString(t Type) string {
+	switch t {
+	case Major:
+		return TypeStrings[0]
+	case Minor:
+		return TypeStrings[1]
+	case Patch:
+		return TypeStrings[2]
+	case Auto:
+		return TypeStrings[3]
+	}
+	return ""
+}
+
 func (v *Version) SetSemverPtr(semverPtr SemverInterface) {
 	v.semverPtr = semverPtr
 }
 
+// SetPrereleaseIdentifier sets the identifier of BranchPrerelease prereleases, e.g. feat-login for 1.5.0-feat-login.3
+func (v *Version) SetPrereleaseIdentifier(identifier string) error {
+	if err := ValidatePrereleaseIdentifier(identifier); err != nil {
+		return err
+	}
+	v.identifier = identifier
+	return nil
+}
+
 func New(versionString string) (*Version, error) {
 	versionString = strings.TrimLeft(versionString, "vV")
 	console.Debug("Version.New()", fmt.Sprintf("get version from string %s \n", versionString))
@@ -142,7 +172,18 @@ func (v *Version) Prerelease(PrereleaseType PrereleaseType, PrereleaseMetadata s
 	if PrereleaseType == NotAPrerelease {
 		return errors.New(ErrStrPrereleaseEmptyType)
 	}
-	if v.IsPrerelease() {
+	if PrereleaseType == BranchPrerelease {
+		if v.identifier == "" {
+			return errors.New(ErrStrPrereleaseEmptyIdentifier)
+		}
+		// a prerelease of another identifier starts over from the identifier of the branch
+		if v.prereleaseIdentifier() != v.identifier {
+			err := v.SetPrereleaseString(v.identifier)
+			if err != nil {
+				return err
+			}
+		}
+	} else if v.IsPrerelease() {
 		Prerelease, err := v.GetPrerelease()
 		if err != nil {
 			return err
@@ -197,6 +238,16 @@ func (v *Version) Prerelease(PrereleaseType PrereleaseType, PrereleaseMetadata s
 	return nil
 }
 
+// prereleaseIdentifier returns the prerelease without its counter, e.g. feat-login for 1.5.0-feat-login.3
+func (v *Version) prereleaseIdentifier() string {
+	prerelease := v.GetPrereleaseString()
+	i := strings.LastIndex(prerelease, ".")
+	if i >= 0 && containsOnly(prerelease[i+1:], num) {
+		return prerelease[:i]
+	}
+	return prerelease
+}
+
 func (v *Version) String() string {
 	if v.semverPtr == nil {
 		return ""
@@ -166,6 +166,98 @@ func TestVersion_PrereleaseWithUnsupportedType(t *testing.T) {
 	a.ErrorContains(err, fmt.Sprintf(version.ErrStrFormattedUnsupportedReleaseType, 43))
 }
 
+func TestVersion_BranchPrerelease(t *testing.T) {
+	a := assert.New(t)
+	v, err := version.New("1.4.2")
+	a.Empty(err)
+	err = v.Increment(version.Minor, version.BranchPrerelease, "")
+	a.ErrorContains(err, version.ErrStrPrereleaseEmptyIdentifier)
+
+	v, err = version.New("1.4.2")
+	a.Empty(err)
+	a.Empty(v.SetPrereleaseIdentifier("feat-login"))
+	err = v.Increment(version.Minor, version.BranchPrerelease, "")
+	a.Empty(err)
+	a.Equal("1.5.0-feat-login.0", v.String())
+
+	v, err = version.New("1.5.0-feat-login.3+build")
+	a.Empty(err)
+	a.Empty(v.SetPrereleaseIdentifier("feat-login"))
+	err = v.Increment(version.NotAVersion, version.BranchPrerelease, "")
+	a.Empty(err)
+	a.Equal("1.5.0-feat-login.4", v.String())
+
+	v, err = version.New("1.5.0-feat-login.3")
+	a.Empty(err)
+	a.Empty(v.SetPrereleaseIdentifier("feat-signup"))
+	err = v.Increment(version.NotAVersion, version.BranchPrerelease, "")
+	a.Empty(err)
+	a.Equal("1.5.0-feat-signup.0", v.String())
+
+	v, err = version.New("1.5.0-beta.2")
+	a.Empty(err)
+	a.Empty(v.SetPrereleaseIdentifier("feat.v2.login"))
+	err = v.Increment(version.NotAVersion, version.BranchPrerelease, "")
+	a.Empty(err)
+	a.Equal("1.5.0-feat.v2.login.0", v.String())
+
+	v, err = version.New("1.5.0-feat-login.3")
+	a.Empty(err)
+	a.Empty(v.SetPrereleaseIdentifier("feat-login"))
+	err = v.Increment(version.Patch, version.BranchPrerelease, "")
+	a.Empty(err)
+	a.Equal("1.5.1-feat-login.0", v.String())
+
+	v, err = version.New("1.4.2")
+	a.Empty(err)
+	a.Empty(v.SetPrereleaseIdentifier("feat-login"))
+	err = v.Increment(version.NotAVersion, version.BranchPrerelease, "")
+	a.ErrorContains(err, version.ErrStrPreReleasingNonPrerelease)
+}
+
+func TestVersion_PrereleaseIdentifier(t *testing.T) {
+	type test struct {
+		Name               string
+		ExpectedIdentifier string
+		ExpectedErrorStr   string
+	}
+
+	suite := map[string]test{
+		"Plain":             {Name: "develop", ExpectedIdentifier: "develop"},
+		"Slash":             {Name: "feat/login", ExpectedIdentifier: "feat-login"},
+		"Upper Case":        {Name: "Feat/Login_Form", ExpectedIdentifier: "feat-login-form"},
+		"Runs Of Symbols":   {Name: "--fix//#42..crash--", ExpectedIdentifier: "fix-42-crash"},
+		"Unicode":           {Name: "feat/café", ExpectedIdentifier: "feat-caf"},
+		"Leading Zero":      {Name: "007-agent", ExpectedIdentifier: "007-agent"},
+		"Numeric":           {Name: "1234", ExpectedIdentifier: "1234", ExpectedErrorStr: fmt.Sprintf(version.ErrStrFormattedInvalidPrereleaseIdentifier, "1234")},
+		"Nothing Left":      {Name: "///", ExpectedIdentifier: "", ExpectedErrorStr: fmt.Sprintf(version.ErrStrFormattedInvalidPrereleaseIdentifier, "")},
+		"Dotted Identifier": {Name: "feat.v2", ExpectedIdentifier: "feat-v2"},
+	}
+
+	var counter int
+	for name, test := range suite {
+		counter++
+		t.Logf("Test Case %v/%v - %s", counter, len(suite), name)
+		a := assert.New(t)
+
+		identifier := version.SanitizePrereleaseIdentifier(test.Name)
+		a.Equal(test.ExpectedIdentifier, identifier)
+		err := version.ValidatePrereleaseIdentifier(identifier)
+		if test.ExpectedErrorStr != "" {
+			a.ErrorContains(err, test.ExpectedErrorStr)
+			continue
+		}
+		a.Nil(err)
+	}
+
+	a := assert.New(t)
+	a.Nil(version.ValidatePrereleaseIdentifier("feat.v2.login"))
+	a.Error(version.ValidatePrereleaseIdentifier("feat.01.login"))
+	a.Error(version.ValidatePrereleaseIdentifier("feat..login"))
+	a.Error(version.ValidatePrereleaseIdentifier("feat.3"))
+	a.Error(version.ValidatePrereleaseIdentifier("feat_login"))
+}
+
 func TestVersion_PrereleaseAPrereleaseWithMajorResetsPrCounter(t *testing.T) {
 	a := assert.New(t)
 
@@ -439,14 +531,25 @@ func TestBump_StringToVersionType(t *testing.T) {
 	a.Equal(version.FromString("major"), version.Major)
 	a.Equal(version.FromString("minor"), version.Minor)
 	a.Equal(version.FromString("patch"), version.Patch)
+	a.Equal(version.FromString("auto"), version.Auto)
 	a.Equal(version.FromString("nonexistent"), version.NotAVersion)
 }
 
+func TestBump_VersionTypeToString(t *testing.T) {
+	a := assert.New(t)
+	a.Equal(version.TypeString(version.Major), "major")
+	a.Equal(version.TypeString(version.Minor), "minor")
+	a.Equal(version.TypeString(version.Patch), "patch")
+	a.Equal(version.TypeString(version.Auto), "auto")
+	a.Equal(version.TypeString(version.NotAVersion), "")
+}
+
 func TestBump_PrereleaseString(t *testing.T) {
 	a := assert.New(t)
 	a.Equal(version.PrereleaseString(version.AlphaPrerelease), "alpha")
 	a.Equal(version.PrereleaseString(version.BetaPrerelease), "beta")
 	a.Equal(version.PrereleaseString(version.ReleaseCandidate), "rc")
+	a.Equal(version.PrereleaseString(version.BranchPrerelease), "branch")
 	a.Equal(version.PrereleaseString(version.NotAPrerelease), "")
 }
 
@@ -455,6 +558,7 @@ func TestBump_FromPrereleaseString(t *testing.T) {
 	a.Equal(version.FromPrereleaseTypeString("alpha"), version.AlphaPrerelease)
 	a.Equal(version.FromPrereleaseTypeString("beta"), version.BetaPrerelease)
 	a.Equal(version.FromPrereleaseTypeString("rc"), version.ReleaseCandidate)
+	a.Equal(version.FromPrereleaseTypeString("branch"), version.BranchPrerelease)
 	a.Equal(version.FromPrereleaseTypeString(""), version.NotAPrerelease)
 }
 